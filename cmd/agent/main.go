@@ -20,6 +20,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -33,12 +34,19 @@ import (
 	"time"
 
 	"github.com/iabtechlab/agentic-rtb-framework/internal/agent"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/federation"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/handlers"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/health"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/manifest"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/mcp"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/metrics"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/privacy"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/web"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -69,6 +77,33 @@ var (
 
 	// Federation configuration
 	federationConfig = flag.String("federation-config", "", "Path to federation configuration file (YAML/JSON)")
+	policyConfig     = flag.String("policy-config", "", "Path to federation policy file (YAML/JSON)")
+	privacyConfig    = flag.String("privacy-config", "", "Path to privacy requirements file for handlers and endpoints (YAML/JSON)")
+	federationWatch  = flag.Int("federation-watch-seconds", 5, "Seconds between checks of the federation config file for changes (0 disables; SIGHUP always reloads)")
+
+	// Mutation audit log sinks
+	auditLog        = flag.String("audit-log", "", "Path to the JSONL mutation audit log (empty disables)")
+	auditMaxSizeMB  = flag.Int("audit-max-size-mb", 100, "Size in MB at which the audit log is rotated (0 disables rotation)")
+	auditMaxBackups = flag.Int("audit-max-backups", 5, "Number of rotated audit log files to keep")
+	auditStdout     = flag.Bool("audit-stdout", false, "Write mutation audit records to stdout as JSON lines")
+	auditBuffer     = flag.Int("audit-buffer", 1000, "Number of recent audit records kept in memory for the web UI (0 disables)")
+
+	// Agent manifest (defaults to the manifest embedded in the binary)
+	manifestFile = flag.String("manifest", "", "Path to the agent-manifest JSON file")
+	manifestJSON = flag.String("manifest-json", "", "Agent-manifest JSON document, e.g. the value of the container's agent-manifest label")
+
+	// OpenTelemetry tracing
+	traceExporter    = flag.String("trace-exporter", "none", "Span exporter: none, otlp, stdout or file")
+	traceEndpoint    = flag.String("trace-otlp-endpoint", "", "OTLP gRPC collector address (default: OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317)")
+	traceInsecure    = flag.Bool("trace-otlp-insecure", false, "Connect to the OTLP collector without TLS")
+	traceFile        = flag.String("trace-file", "", "File receiving spans as JSON lines when --trace-exporter=file")
+	traceSampleRatio = flag.Float64("trace-sample-ratio", 1.0, "Fraction of new traces sampled; traces started by callers follow their sampling decision")
+
+	// Portion of tmax reserved for response delivery; handlers run within the remainder
+	tmaxSafetyMarginMs = flag.Int("tmax-safety-margin-ms", 10, "Milliseconds of tmax reserved for response delivery")
+
+	// Requests processed at once on a StreamMutations stream; further requests wait
+	streamConcurrency = flag.Int("stream-concurrency", agent.DefaultStreamConcurrency, "Requests processed at once on each StreamMutations stream")
 
 	// Version flag
 	showVersion = flag.Bool("version", false, "Show version information")
@@ -91,16 +126,92 @@ func main() {
 		log.Fatal("At least one service must be enabled (--enable-grpc, --enable-mcp, or --enable-web)")
 	}
 
+	// Configure tracing before any server or federation client is created
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:       *traceExporter,
+		Endpoint:       *traceEndpoint,
+		Insecure:       *traceInsecure,
+		File:           *traceFile,
+		SampleRatio:    *traceSampleRatio,
+		ServiceName:    "artf-agent",
+		ServiceVersion: Version,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure tracing: %v", err)
+	}
+	if *traceExporter != tracing.ExporterNone {
+		log.Printf("Tracing enabled with %s exporter", *traceExporter)
+	}
+
 	// Create shared components
 	healthChecker := health.NewChecker()
 	mutationHandlers := handlers.NewMutationHandlers()
 
+	// Load the agent manifest and check it declares every intent the handlers emit
+	agentManifest, err := loadManifest()
+	if err != nil {
+		log.Fatalf("Failed to load agent manifest: %v", err)
+	}
+	var emitted []string
+	for _, intent := range mutationHandlers.Intents() {
+		emitted = append(emitted, intent.String())
+	}
+	mismatch := agentManifest.CompareIntents(emitted)
+	if len(mismatch.Undeclared) > 0 {
+		log.Fatalf("Agent manifest does not declare intents emitted by handlers: %v", mismatch.Undeclared)
+	}
+	if len(mismatch.Unused) > 0 {
+		log.Printf("Warning: agent manifest declares intents no handler emits: %v", mismatch.Unused)
+	}
+	log.Printf("Agent manifest %s v%s declares intents %v", agentManifest.Name, agentManifest.Version, agentManifest.IntentNames())
+
 	// Create the ARTF agent (shared by both gRPC and MCP interfaces)
 	// This ensures a single implementation for all business logic
 	artfAgent := agent.NewARTFAgent(mutationHandlers)
+	artfAgent.SetSafetyMargin(time.Duration(*tmaxSafetyMarginMs) * time.Millisecond)
+	artfAgent.SetStreamConcurrency(*streamConcurrency)
+
+	// Record every proposed mutation and its decision. The metrics collector
+	// counts every record as it is logged, so acceptance rates are exposed at
+	// /metrics even when the audit queue drops records.
+	agentMetrics := metrics.New()
+	var auditSinks []audit.Sink
+	var auditRing *audit.RingBuffer
+	if *auditLog != "" {
+		sink, err := audit.NewFileSink(*auditLog, int64(*auditMaxSizeMB)*1024*1024, *auditMaxBackups)
+		if err != nil {
+			log.Fatalf("Failed to open audit log: %v", err)
+		}
+		auditSinks = append(auditSinks, sink)
+		log.Printf("Audit log writing to %s", *auditLog)
+	}
+	if *auditStdout {
+		auditSinks = append(auditSinks, audit.NewWriterSink(os.Stdout))
+	}
+	if *auditBuffer > 0 {
+		auditRing = audit.NewRingBuffer(*auditBuffer)
+		auditSinks = append(auditSinks, auditRing)
+	}
+	auditLogger := audit.NewLogger(auditSinks...)
+	auditLogger.SetCounter(agentMetrics)
+	agentMetrics.WatchAuditLogger(auditLogger)
+	artfAgent.SetAuditLogger(auditLogger)
+
+	// Enforce privacy signals before handlers and federated endpoints run
+	var privacyGate *privacy.Gate
+	if *privacyConfig != "" {
+		gate, err := privacy.NewGateFromFile(*privacyConfig)
+		if err != nil {
+			log.Fatalf("Failed to load privacy config: %v", err)
+		}
+		privacyGate = gate
+		artfAgent.SetPrivacyGate(privacyGate)
+		log.Printf("Privacy gate loaded from %s", *privacyConfig)
+	}
 
 	// Track services for shutdown
 	var grpcServer *grpc.Server
+	var grpcHealthServer *grpchealth.Server
 	var mcpAgent *mcp.Agent
 	var webServer *http.Server
 	var healthServer *http.Server
@@ -108,12 +219,18 @@ func main() {
 	// Start gRPC interface
 	if *enableGRPC {
 		grpcServer = grpc.NewServer(
+			grpc.StatsHandler(tracing.ServerHandler()),
 			grpc.UnaryInterceptor(agent.LoggingInterceptor),
+			grpc.StreamInterceptor(agent.StreamLoggingInterceptor),
 		)
 
 		agent.RegisterRTBExtensionPointServer(grpcServer, artfAgent)
 		reflection.Register(grpcServer)
 
+		// Standard gRPC health service so orchestrators can probe this agent
+		grpcHealthServer = grpchealth.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, grpcHealthServer)
+
 		grpcListenAddr := fmt.Sprintf("%s:%d", *listenAddr, *grpcPort)
 		grpcListener, err := net.Listen("tcp", grpcListenAddr)
 		if err != nil {
@@ -140,11 +257,50 @@ func main() {
 		}
 	}
 
+	// Attach the orchestrator policy if configured
+	if federationManager != nil && *policyConfig != "" {
+		policy, err := federation.NewPolicyEngineFromFile(*policyConfig)
+		if err != nil {
+			log.Fatalf("Failed to load federation policy: %v", err)
+		}
+		federationManager.SetPolicy(policy)
+		log.Printf("Federation policy loaded from %s", *policyConfig)
+	}
+
+	if federationManager != nil && privacyGate != nil {
+		federationManager.SetPrivacyGate(privacyGate)
+	}
+	if federationManager != nil {
+		federationManager.SetAuditLogger(auditLogger)
+		federationManager.SetMetrics(agentMetrics)
+	}
+
+	// Reload the federation config when the file changes or on SIGHUP
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if federationManager != nil {
+		if *federationWatch > 0 {
+			go federationManager.Watch(watchCtx, *federationConfig, time.Duration(*federationWatch)*time.Second)
+		}
+
+		hupChan := make(chan os.Signal, 1)
+		signal.Notify(hupChan, syscall.SIGHUP)
+		go func() {
+			for range hupChan {
+				log.Printf("SIGHUP received, reloading federation config from %s", *federationConfig)
+				if err := federationManager.ReloadFromFile(*federationConfig); err != nil {
+					log.Printf("Federation reload failed, keeping current config: %v", err)
+				}
+			}
+		}()
+	}
+
 	// When both Web and MCP are enabled, serve them on the same port (web port)
 	// This allows an external load balancer to route to a single endpoint
 	if *enableWeb && *enableMCP {
 		// Create MCP agent that wraps the gRPC agent (single implementation)
 		mcpAgent = mcp.NewAgent(artfAgent, *listenAddr, *webPort)
+		mcpAgent.SetManifest(agentManifest)
 
 		// Attach federation manager if configured
 		if federationManager != nil {
@@ -159,6 +315,9 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to create web handler: %v", err)
 		}
+		if auditRing != nil {
+			webHandler.SetAuditLog(auditRing)
+		}
 
 		// Create unified mux with both Web and MCP routes
 		webMux := http.NewServeMux()
@@ -185,6 +344,7 @@ func main() {
 		if *enableMCP {
 			// MCP agent wraps the gRPC agent (single implementation)
 			mcpAgent = mcp.NewAgent(artfAgent, *listenAddr, *mcpPort)
+			mcpAgent.SetManifest(agentManifest)
 
 			// Attach federation manager if configured
 			if federationManager != nil {
@@ -210,6 +370,9 @@ func main() {
 			if err != nil {
 				log.Fatalf("Failed to create web handler: %v", err)
 			}
+			if auditRing != nil {
+				webHandler.SetAuditLog(auditRing)
+			}
 
 			webMux := http.NewServeMux()
 			webHandler.RegisterRoutes(webMux)
@@ -244,6 +407,11 @@ func main() {
 		fmt.Fprintf(w, `{"version":"%s","build_time":"%s","grpc":%v,"mcp":%v,"web":%v,"external_url":"%s"}`,
 			Version, BuildTime, *enableGRPC, *enableMCP, *enableWeb, externalURLVal)
 	})
+	healthMux.Handle("/metrics", agentMetrics.Handler())
+	healthMux.HandleFunc("/manifest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(agentManifest)
+	})
 
 	healthListenAddr := fmt.Sprintf("%s:%d", *listenAddr, *healthPort)
 	healthServer = &http.Server{
@@ -276,6 +444,9 @@ func main() {
 
 	// Mark as not ready during shutdown
 	healthChecker.SetReady(false)
+	if grpcHealthServer != nil {
+		grpcHealthServer.Shutdown()
+	}
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -296,6 +467,7 @@ func main() {
 	}
 
 	if federationManager != nil {
+		stopWatch()
 		if err := federationManager.Close(); err != nil {
 			log.Printf("Federation manager shutdown error: %v", err)
 		} else {
@@ -307,9 +479,32 @@ func main() {
 		log.Printf("Health endpoint shutdown error: %v", err)
 	}
 
+	if err := auditLogger.Close(); err != nil {
+		log.Printf("Audit log close error: %v", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Tracing shutdown error: %v", err)
+	}
+
 	log.Printf("Agent stopped")
 }
 
+// loadManifest returns the agent manifest from --manifest-json, --manifest or
+// the manifest embedded in the binary, in that order of precedence
+func loadManifest() (*manifest.Manifest, error) {
+	switch {
+	case *manifestJSON != "":
+		return manifest.Parse([]byte(*manifestJSON))
+	case *manifestFile != "":
+		return manifest.Load(*manifestFile)
+	default:
+		m := manifest.Default()
+		m.Version = Version
+		return m, nil
+	}
+}
+
 // printServiceURLs prints the URLs for enabled services
 func printServiceURLs() {
 	log.Println("=== Service URLs ===")
@@ -23,82 +23,158 @@ import (
 	"log"
 	"time"
 
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/handlers"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/privacy"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/validate"
 	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
+// DefaultSafetyMargin is the portion of tmax reserved for serialization and
+// network latency. Handlers must finish within tmax minus this margin.
+const DefaultSafetyMargin = 10 * time.Millisecond
+
 // ARTFAgent implements the RTBExtensionPoint gRPC service
 type ARTFAgent struct {
 	pb.UnimplementedRTBExtensionPointServer
-	handlers *handlers.MutationHandlers
+	handlers     *handlers.MutationHandlers
+	safetyMargin time.Duration
+	dropped      *validate.Counter
+	privacy      *privacy.Gate
+	audit        *audit.Logger
+
+	streamConcurrency int
+}
+
+// handlerResult carries the output of a single handler run
+type handlerResult struct {
+	name      string
+	mutations []*pb.Mutation
+	err       error
 }
 
 // NewARTFAgent creates a new ARTF agent instance
 func NewARTFAgent(h *handlers.MutationHandlers) *ARTFAgent {
 	return &ARTFAgent{
-		handlers: h,
+		handlers:     h,
+		safetyMargin: DefaultSafetyMargin,
+		dropped:      validate.NewCounter(),
+
+		streamConcurrency: DefaultStreamConcurrency,
 	}
 }
 
+// SetSafetyMargin sets the portion of tmax reserved for response delivery
+func (a *ARTFAgent) SetSafetyMargin(margin time.Duration) {
+	a.safetyMargin = margin
+}
+
+// SetPrivacyGate sets the gate that decides which handlers may process a request
+func (a *ARTFAgent) SetPrivacyGate(gate *privacy.Gate) {
+	a.privacy = gate
+}
+
+// SetAuditLogger sets the logger that records every mutation proposed by a handler
+func (a *ARTFAgent) SetAuditLogger(logger *audit.Logger) {
+	a.audit = logger
+}
+
+// DroppedMutations returns the counter of handler mutations dropped for not
+// conforming to the intent, operation and payload rules
+func (a *ARTFAgent) DroppedMutations() *validate.Counter {
+	return a.dropped
+}
+
 // GetMutations processes an RTB request and returns proposed mutations.
 // Respects applicable_intents from the request to filter which mutation types are returned.
 func (a *ARTFAgent) GetMutations(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
 	startTime := time.Now()
 
-	// Check timeout budget
+	ctx, span := tracing.Tracer().Start(ctx, "ARTFAgent.GetMutations",
+		trace.WithAttributes(tracing.RequestAttributes(req)...))
+	defer span.End()
+
+	// Check timeout budget, reserving a safety margin for response delivery
 	tmax := req.GetTmax()
 	if tmax > 0 {
-		deadline := time.Now().Add(time.Duration(tmax) * time.Millisecond)
+		deadline := time.Now().Add(a.handlerBudget(tmax))
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithDeadline(ctx, deadline)
 		defer cancel()
 	}
 
-	// Collect mutations from all registered handlers
-	var mutations []*pb.Mutation
-
 	// Process based on lifecycle stage
 	lifecycle := req.GetLifecycle()
-	bidRequest := req.GetBidRequest()
-	bidResponse := req.GetBidResponse()
-
-	// NOTE: applicable_intents is defined in the proto spec but not yet in the generated Go code.
-	// After regenerating protos with `make bindings`, use: applicableIntents := req.GetApplicableIntents()
-	// For now, pass nil which means all intents are applicable.
-	var applicableIntents []pb.Intent
-
-	log.Printf("Processing request %s at lifecycle stage %v with applicable_intents=%v",
-		req.GetId(), lifecycle, applicableIntents)
-
-	// Run segment activation handler
-	if segmentMutations, err := a.handlers.ProcessSegments(ctx, bidRequest, applicableIntents); err == nil {
-		mutations = append(mutations, segmentMutations...)
-	} else {
-		log.Printf("Segment processing error: %v", err)
-	}
 
-	// Run deal activation handler
-	if dealMutations, err := a.handlers.ProcessDeals(ctx, bidRequest, applicableIntents); err == nil {
-		mutations = append(mutations, dealMutations...)
-	} else {
-		log.Printf("Deal processing error: %v", err)
+	// An empty applicable_intents list means all intents are applicable
+	applicableIntents := req.GetApplicableIntents()
+	originator := req.GetOriginator()
+
+	log.Printf("Processing request %s at lifecycle stage %v from originator %v/%s with applicable_intents=%v",
+		req.GetId(), lifecycle, originator.GetType(), originator.GetId(), applicableIntents)
+
+	// Audit records refer to the request as received
+	received := req
+
+	// Run every handler registered for this lifecycle stage and intent set in parallel
+	selected := a.handlers.Select(lifecycle, applicableIntents)
+
+	// Only handlers the privacy signals permit run, and they see the scrubbed request
+	if a.privacy != nil {
+		assessment := a.privacy.Assess(req)
+		var permitted []handlers.Handler
+		for _, h := range selected {
+			if a.privacy.Allow(assessment, privacy.KindHandler, h.Name()).Allowed {
+				permitted = append(permitted, h)
+			}
+		}
+		selected = permitted
+		req = privacy.Scrub(req, assessment)
+	}
+	resultChan := make(chan handlerResult, len(selected))
+	for _, h := range selected {
+		go func(h handlers.Handler) {
+			ctx, span := tracing.Tracer().Start(ctx, "handler "+h.Name(),
+				trace.WithAttributes(attribute.String("artf.handler", h.Name())))
+			defer span.End()
+			handlerMutations, err := h.Process(ctx, req, applicableIntents)
+			tracing.RecordError(span, err)
+			span.SetAttributes(attribute.Int("artf.mutations", len(handlerMutations)))
+			resultChan <- handlerResult{name: h.Name(), mutations: handlerMutations, err: err}
+		}(h)
 	}
 
-	// Run bid shading handler (if bid response is present)
-	if bidResponse != nil {
-		if bidMutations, err := a.handlers.ProcessBidShading(ctx, bidRequest, bidResponse, applicableIntents); err == nil {
-			mutations = append(mutations, bidMutations...)
-		} else {
-			log.Printf("Bid shading error: %v", err)
+	// Collect mutations until all handlers finish or the deadline fires
+	var mutations []*pb.Mutation
+	finished := make(map[string]bool, len(selected))
+collect:
+	for len(finished) < len(selected) {
+		select {
+		case result := <-resultChan:
+			finished[result.name] = true
+			if result.err != nil {
+				log.Printf("Handler %s error: %v", result.name, result.err)
+				continue
+			}
+			mutations = append(mutations, a.validate(received, result)...)
+		case <-ctx.Done():
+			break collect
 		}
 	}
 
-	// Run content data handler for ADD_CIDS
-	if contentMutations, err := a.handlers.ProcessContentData(ctx, bidRequest, applicableIntents); err == nil {
-		mutations = append(mutations, contentMutations...)
-	} else {
-		log.Printf("Content data processing error: %v", err)
+	// Report handlers that did not finish in time
+	var timedOut []string
+	for _, h := range selected {
+		if !finished[h.Name()] {
+			timedOut = append(timedOut, h.Name())
+		}
+	}
+	if len(timedOut) > 0 {
+		log.Printf("Request %s deadline reached, handlers timed out: %v", req.GetId(), timedOut)
 	}
 
 	// Build response
@@ -106,17 +182,60 @@ func (a *ARTFAgent) GetMutations(ctx context.Context, req *pb.RTBRequest) (*pb.R
 		Id:        req.Id,
 		Mutations: mutations,
 		Metadata: &pb.Metadata{
-			ApiVersion:   stringPtr("1.0"),
-			ModelVersion: stringPtr("v0.10.0"),
+			ApiVersion:       stringPtr("1.0"),
+			ModelVersion:     stringPtr("v0.10.0"),
+			TimedOutHandlers: timedOut,
 		},
 	}
 
+	span.SetAttributes(
+		attribute.Int("artf.handlers", len(selected)),
+		attribute.Int("artf.mutations", len(mutations)),
+		attribute.StringSlice("artf.timed_out_handlers", timedOut),
+	)
+
 	log.Printf("Request %s processed in %v, returning %d mutations",
 		req.GetId(), time.Since(startTime), len(mutations))
 
 	return response, nil
 }
 
+// validate drops a handler's mutations that do not conform to the
+// intent/operation/payload rules and audits every proposed mutation
+func (a *ARTFAgent) validate(req *pb.RTBRequest, result handlerResult) []*pb.Mutation {
+	valid, violations := validate.Filter(result.mutations)
+	if len(violations) > 0 {
+		a.dropped.Add(violations)
+		for _, v := range violations {
+			log.Printf("Request %s dropped non-conforming %s from handler %s", req.GetId(), v, result.name)
+		}
+	}
+
+	if a.audit != nil {
+		records := make([]audit.Record, 0, len(result.mutations))
+		for _, m := range valid {
+			records = append(records, audit.NewRecord(req, audit.SourceHandler, result.name, m, true, audit.StageValidation, "", ""))
+		}
+		for _, v := range violations {
+			records = append(records, audit.NewRecord(req, audit.SourceHandler, result.name, v.Mutation, false, audit.StageValidation,
+				string(v.Reason), v.Detail))
+		}
+		a.audit.Log(records...)
+	}
+	return valid
+}
+
+// handlerBudget returns the time handlers may run for a request with the given tmax.
+// If the safety margin would consume the whole budget, half of tmax is used instead.
+func (a *ARTFAgent) handlerBudget(tmax int32) time.Duration {
+	total := time.Duration(tmax) * time.Millisecond
+	budget := total - a.safetyMargin
+	if budget <= 0 {
+		budget = total / 2
+	}
+	return budget
+}
+
 // LoggingInterceptor logs gRPC requests
 func LoggingInterceptor(
 	ctx context.Context,
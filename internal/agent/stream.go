@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package agent
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/grpc"
+)
+
+// DefaultStreamConcurrency is the number of requests processed at once on a
+// single StreamMutations stream
+const DefaultStreamConcurrency = 64
+
+// SetStreamConcurrency sets the number of requests processed at once on a
+// single StreamMutations stream
+func (a *ARTFAgent) SetStreamConcurrency(n int) {
+	if n <= 0 {
+		n = DefaultStreamConcurrency
+	}
+	a.streamConcurrency = n
+}
+
+// StreamMutations processes a stream of RTB requests and sends the response to
+// each as soon as it is ready, correlated by id. Every request is processed
+// within its own tmax. Once the stream concurrency is reached no further
+// requests are read until one finishes, so gRPC flow control slows down a
+// sender that outpaces the handlers.
+func (a *ARTFAgent) StreamMutations(stream pb.RTBExtensionPoint_StreamMutationsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	slots := make(chan struct{}, a.streamConcurrency)
+	var wg sync.WaitGroup
+	var sendMu sync.Mutex
+	var sendErr error
+	requests := 0
+
+	finish := func(err error) error {
+		wg.Wait()
+		log.Printf("Stream closed after %d requests", requests)
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return finish(ctx.Err())
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return finish(nil)
+		}
+		if err != nil {
+			cancel()
+			return finish(err)
+		}
+		requests++
+
+		wg.Add(1)
+		go func(req *pb.RTBRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			// The stream's metadata carries the trace context of whoever
+			// opened it; each request carries its own in its ext
+			resp, _ := a.GetMutations(tracing.ExtractRequest(ctx, req), req)
+
+			// Send is not safe for concurrent use
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if sendErr != nil {
+				return
+			}
+			if err := stream.Send(resp); err != nil {
+				log.Printf("Stream failed to send response to request %s: %v", req.GetId(), err)
+				sendErr = err
+				cancel()
+			}
+		}(req)
+	}
+}
+
+// StreamLoggingInterceptor logs gRPC streams
+func StreamLoggingInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	log.Printf("gRPC stream %s took %v, error: %v", info.FullMethod, time.Since(start), err)
+	return err
+}
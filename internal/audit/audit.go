@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package audit records a structured, append-only trail of every proposed
+// mutation and the decision taken on it
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/protobuf/proto"
+)
+
+// Source kinds that propose mutations
+const (
+	SourceHandler  = "handler"
+	SourceEndpoint = "endpoint"
+)
+
+// Decision stages
+const (
+	// StageValidation is the intent, operation and payload conformance check
+	StageValidation = "validation"
+
+	// StagePolicy is the orchestrator policy for federated mutations
+	StagePolicy = "policy"
+
+	// StageConflict is the merging of contradictory federated mutations
+	StageConflict = "conflict"
+
+	// StagePipeline is the application of a pipeline stage's mutations to the request
+	StagePipeline = "pipeline"
+
+	// StageIntent is the filtering of federated mutations by the caller's acceptable intents
+	StageIntent = "intent"
+)
+
+// Record is the audit entry for a single proposed mutation
+type Record struct {
+	Time           time.Time `json:"time"`
+	RequestID      string    `json:"request_id"`
+	OriginatorType string    `json:"originator_type,omitempty"`
+	OriginatorID   string    `json:"originator_id,omitempty"`
+	Lifecycle      string    `json:"lifecycle"`
+	Source         string    `json:"source"`
+	Endpoint       string    `json:"endpoint"`
+	Intent         string    `json:"intent"`
+	Op             string    `json:"op"`
+	Path           string    `json:"path"`
+	PayloadHash    string    `json:"payload_hash,omitempty"`
+	Accepted       bool      `json:"accepted"`
+	Stage          string    `json:"stage"`
+	Code           string    `json:"code,omitempty"`
+	Reason         string    `json:"reason,omitempty"`
+}
+
+// NewRecord creates the audit record for a mutation proposed by a handler or
+// endpoint. For rejections, code is the short rejection reason or policy rule
+// and reason the human-readable explanation.
+func NewRecord(req *pb.RTBRequest, source, endpoint string, m *pb.Mutation, accepted bool, stage, code, reason string) Record {
+	return Record{
+		Time:           time.Now().UTC(),
+		RequestID:      req.GetId(),
+		OriginatorType: req.GetOriginator().GetType().String(),
+		OriginatorID:   req.GetOriginator().GetId(),
+		Lifecycle:      req.GetLifecycle().String(),
+		Source:         source,
+		Endpoint:       endpoint,
+		Intent:         m.GetIntent().String(),
+		Op:             m.GetOp().String(),
+		Path:           m.GetPath(),
+		PayloadHash:    PayloadHash(m),
+		Accepted:       accepted,
+		Stage:          stage,
+		Code:           code,
+		Reason:         reason,
+	}
+}
+
+// PayloadHash returns the SHA-256 of the deterministic encoding of a
+// mutation's payload, or "" if it has none
+func PayloadHash(m *pb.Mutation) string {
+	r := m.ProtoReflect()
+	oneof := r.Descriptor().Oneofs().ByName("value")
+	if oneof == nil {
+		return ""
+	}
+	fd := r.WhichOneof(oneof)
+	if fd == nil {
+		return ""
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(r.Get(fd).Message().Interface())
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Sink receives audit records
+type Sink interface {
+	// Write appends records to the sink
+	Write(records []Record) error
+
+	// Close flushes and releases the sink
+	Close() error
+}
+
+// Counter tallies audit records. Unlike sinks, it is called on the request
+// path as records are logged, so it also sees records the queue drops.
+type Counter interface {
+	Count(records []Record)
+}
+
+// DefaultQueueSize is the number of Log calls that can wait to be written
+// before further records are dropped
+const DefaultQueueSize = 4096
+
+// Logger fans audit records out to its sinks. Records are queued and written
+// by a background goroutine, so slow sinks and file rotation do not delay
+// requests; records logged while the queue is full are dropped and counted.
+// A nil Logger discards records.
+type Logger struct {
+	sinks   []Sink
+	counter Counter
+	queue   chan []Record
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewLogger creates an audit logger writing to the given sinks
+func NewLogger(sinks ...Sink) *Logger {
+	l := &Logger{
+		sinks: sinks,
+		queue: make(chan []Record, DefaultQueueSize),
+		done:  make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// SetCounter sets the counter told about every logged record. It must be
+// called before records are logged.
+func (l *Logger) SetCounter(c Counter) {
+	l.counter = c
+}
+
+// Log counts records and queues them for every sink without blocking
+func (l *Logger) Log(records ...Record) {
+	if l == nil || len(records) == 0 {
+		return
+	}
+	if l.counter != nil {
+		l.counter.Count(records)
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.queue <- records:
+	default:
+		if l.dropped.Add(uint64(len(records))) == uint64(len(records)) {
+			log.Printf("[Audit] Queue full, dropping records")
+		}
+	}
+}
+
+// Dropped returns the number of records dropped because the queue was full
+func (l *Logger) Dropped() uint64 {
+	if l == nil {
+		return 0
+	}
+	return l.dropped.Load()
+}
+
+// run writes queued records to every sink until the queue is closed. Sink
+// errors are logged and do not stop the remaining sinks.
+func (l *Logger) run() {
+	defer close(l.done)
+	for records := range l.queue {
+		for _, sink := range l.sinks {
+			if err := sink.Write(records); err != nil {
+				log.Printf("[Audit] Failed to write %d records: %v", len(records), err)
+			}
+		}
+	}
+}
+
+// Close writes the queued records and closes every sink
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.queue)
+	l.mu.Unlock()
+	<-l.done
+
+	if dropped := l.dropped.Load(); dropped > 0 {
+		log.Printf("[Audit] %d records were dropped because the queue was full", dropped)
+	}
+	var lastErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// WriterSink writes records as JSON lines to a writer, such as os.Stdout
+type WriterSink struct {
+	w io.Writer
+}
+
+// NewWriterSink creates a sink that writes JSON lines to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write encodes each record as one JSON line
+func (s *WriterSink) Write(records []Record) error {
+	enc := json.NewEncoder(s.w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is a no-op; the writer is owned by the caller
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// FileSink appends records as JSON lines to a file, rotating it when it
+// reaches a maximum size. Rotated files are named path.1 (newest) to path.N.
+type FileSink struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink opens path for appending. A maxBytes of 0 disables rotation.
+func NewFileSink(path string, maxBytes int64, maxBackups int) (*FileSink, error) {
+	s := &FileSink{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open opens the current file for appending and records its size
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// Write appends records, rotating first if the file would exceed its maximum size
+func (s *FileSink) Write(records []Record) error {
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+		if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+		n, err := s.file.Write(line)
+		s.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotate shifts the backups, moves the current file to path.1 and starts a new file
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if s.maxBackups > 0 {
+		os.Remove(s.backup(s.maxBackups))
+		for i := s.maxBackups - 1; i >= 1; i-- {
+			os.Rename(s.backup(i), s.backup(i+1))
+		}
+		if err := os.Rename(s.path, s.backup(1)); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	} else if err := os.Truncate(s.path, 0); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return s.open()
+}
+
+// backup returns the name of the i-th rotated file
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// Query selects records from a RingBuffer. Empty fields match everything.
+type Query struct {
+	RequestID string
+	Endpoint  string
+	Intent    string
+	Accepted  *bool
+	Limit     int
+}
+
+// matches reports whether a record satisfies the query
+func (q Query) matches(r Record) bool {
+	if q.RequestID != "" && r.RequestID != q.RequestID {
+		return false
+	}
+	if q.Endpoint != "" && r.Endpoint != q.Endpoint {
+		return false
+	}
+	if q.Intent != "" && !strings.EqualFold(r.Intent, q.Intent) {
+		return false
+	}
+	return q.Accepted == nil || r.Accepted == *q.Accepted
+}
+
+// RingBuffer keeps the most recent records in memory for querying
+type RingBuffer struct {
+	mu      sync.RWMutex
+	records []Record
+	next    int
+	full    bool
+}
+
+// NewRingBuffer creates a buffer holding up to capacity records
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer{records: make([]Record, capacity)}
+}
+
+// Write adds records, overwriting the oldest when the buffer is full
+func (b *RingBuffer) Write(records []Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, r := range records {
+		b.records[b.next] = r
+		b.next = (b.next + 1) % len(b.records)
+		if b.next == 0 {
+			b.full = true
+		}
+	}
+	return nil
+}
+
+// Query returns matching records, newest first
+func (b *RingBuffer) Query(q Query) []Record {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	count := b.next
+	if b.full {
+		count = len(b.records)
+	}
+	result := []Record{}
+	for i := 1; i <= count; i++ {
+		r := b.records[(b.next-i+len(b.records))%len(b.records)]
+		if !q.matches(r) {
+			continue
+		}
+		result = append(result, r)
+		if q.Limit > 0 && len(result) >= q.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// Close is a no-op; records stay queryable
+func (b *RingBuffer) Close() error {
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/manifest"
+)
+
+// Admission statuses
+const (
+	AdmissionAdmitted    = "admitted"
+	AdmissionQuarantined = "quarantined"
+	AdmissionRefused     = "refused"
+)
+
+// Admission actions for endpoints that fail their checks
+const (
+	// AdmissionActionRefuse does not connect to the endpoint
+	AdmissionActionRefuse = "refuse"
+
+	// AdmissionActionQuarantine connects to and probes the endpoint, but only
+	// calls it when it is requested by name
+	AdmissionActionQuarantine = "quarantine"
+)
+
+// maxManifestBytes bounds the size of a fetched manifest
+const maxManifestBytes = 1 << 20
+
+// AdmissionConfig checks each endpoint's agent manifest before it receives
+// traffic. An endpoint is admitted if its manifest declares only intents the
+// orchestrator supports, declares every intent in its applicable_intents, and
+// depends only on services listed in capabilities.
+type AdmissionConfig struct {
+	// Action is applied to endpoints that fail admission: "refuse" (default) or "quarantine"
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// Capabilities are the services this orchestrator provides to agents.
+	// A manifest dependency is satisfied if its name, service or host is listed.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// TimeoutMs bounds the manifest fetch (default: 2000)
+	TimeoutMs int `json:"timeout_ms,omitempty" yaml:"timeout_ms,omitempty"`
+}
+
+// GetAction returns the action for failed endpoints, defaulting to refuse
+func (a *AdmissionConfig) GetAction() string {
+	if a.Action == "" {
+		return AdmissionActionRefuse
+	}
+	return a.Action
+}
+
+// GetTimeout returns the manifest fetch timeout
+func (a *AdmissionConfig) GetTimeout() time.Duration {
+	if a.TimeoutMs <= 0 {
+		return 2 * time.Second
+	}
+	return time.Duration(a.TimeoutMs) * time.Millisecond
+}
+
+// validate checks the action
+func (a *AdmissionConfig) validate() error {
+	switch a.GetAction() {
+	case AdmissionActionRefuse, AdmissionActionQuarantine:
+		return nil
+	default:
+		return fmt.Errorf("admission: invalid action '%s'", a.Action)
+	}
+}
+
+// AdmissionResult is the outcome of an endpoint's admission check
+type AdmissionResult struct {
+	Status    string    `json:"status"`
+	Agent     string    `json:"agent,omitempty"`
+	Reasons   []string  `json:"reasons,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Admitted reports whether the endpoint may receive federated traffic.
+// A nil result means admission control is disabled.
+func (r *AdmissionResult) Admitted() bool {
+	return r == nil || r.Status == AdmissionAdmitted
+}
+
+// admit fetches the endpoint's manifest and checks it, returning nil if
+// admission control is disabled
+func admit(config *AdmissionConfig, ep EndpointConfig) *AdmissionResult {
+	if config == nil {
+		return nil
+	}
+	result := &AdmissionResult{Status: AdmissionAdmitted, CheckedAt: time.Now()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.GetTimeout())
+	defer cancel()
+	m, err := fetchManifest(ctx, ep.ManifestURL)
+	if err != nil {
+		result.Reasons = []string{err.Error()}
+	} else {
+		result.Agent = m.Name + " v" + m.Version
+		result.Reasons = checkManifest(config, ep, m)
+	}
+
+	if len(result.Reasons) > 0 {
+		result.Status = AdmissionRefused
+		if config.GetAction() == AdmissionActionQuarantine {
+			result.Status = AdmissionQuarantined
+		}
+		log.Printf("[Federation] Endpoint '%s' %s by admission control: %s",
+			ep.Name, result.Status, strings.Join(result.Reasons, "; "))
+	} else {
+		log.Printf("[Federation] Endpoint '%s' admitted (%s)", ep.Name, result.Agent)
+	}
+	return result
+}
+
+// fetchManifest retrieves and validates an agent manifest over HTTP
+func fetchManifest(ctx context.Context, url string) (*manifest.Manifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("manifest unavailable: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("manifest unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("manifest unavailable: %s returned %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("manifest unavailable: %w", err)
+	}
+	return manifest.Parse(data)
+}
+
+// checkManifest returns the reasons the manifest fails admission
+func checkManifest(config *AdmissionConfig, ep EndpointConfig, m *manifest.Manifest) []string {
+	var reasons []string
+
+	declared := make(map[string]bool)
+	for _, name := range m.IntentNames() {
+		declared[strings.ToUpper(name)] = true
+		if !isValidIntent(name) {
+			reasons = append(reasons, fmt.Sprintf("declares unsupported intent '%s'", name))
+		}
+	}
+	for _, intent := range ep.ApplicableIntents {
+		if !declared[strings.ToUpper(intent)] {
+			reasons = append(reasons, fmt.Sprintf("applicable intent '%s' is not declared in the manifest", intent))
+		}
+	}
+
+	available := make(map[string]bool, len(config.Capabilities))
+	for _, c := range config.Capabilities {
+		available[c] = true
+	}
+	names := make([]string, 0, len(m.Dependencies))
+	for name := range m.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dep := m.Dependencies[name]
+		if available[name] || (dep.Service != "" && available[dep.Service]) || (dep.Host != "" && available[dep.Host]) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("dependency '%s' is not available", name))
+	}
+	return reasons
+}
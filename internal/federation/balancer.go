@@ -0,0 +1,272 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Load balancing strategies
+const (
+	// LoadBalanceRoundRobin sends requests to each replica in turn
+	LoadBalanceRoundRobin = "round_robin"
+
+	// LoadBalanceLeastOutstanding sends requests to the replica with the fewest in-flight calls
+	LoadBalanceLeastOutstanding = "least_outstanding"
+
+	// LoadBalanceLatencyEWMA sends requests to the replica with the lowest
+	// latency moving average, weighted by its in-flight calls
+	LoadBalanceLatencyEWMA = "latency_ewma"
+)
+
+// ewmaAlpha is the weight of the newest sample in the latency moving average
+const ewmaAlpha = 0.3
+
+// replicaFailureThreshold is the number of consecutive failed calls, other
+// than transport failures, after which a replica is taken out of rotation
+const replicaFailureThreshold = 3
+
+// replica is a connection to a single instance of a federated endpoint
+type replica struct {
+	address   string
+	conn      *grpc.ClientConn
+	rtbClient pb.RTBExtensionPointClient
+	stream    *mutationStream // nil unless streaming is enabled
+
+	outstanding int64 // in-flight calls, updated atomically
+
+	mu                  sync.Mutex
+	healthy             bool
+	ewmaMs              float64
+	requests            uint64
+	failures            uint64
+	lastError           error
+	consecutiveFailures int
+}
+
+// ReplicaInfo reports the state of a single endpoint replica
+type ReplicaInfo struct {
+	Address       string  `json:"address"`
+	Healthy       bool    `json:"healthy"`
+	Outstanding   int64   `json:"outstanding"`
+	LatencyEWMAMs float64 `json:"latency_ewma_ms"`
+	Requests      uint64  `json:"requests"`
+	Failures      uint64  `json:"failures"`
+	LastError     string  `json:"last_error,omitempty"`
+}
+
+// begin marks the start of a call to the replica
+func (r *replica) begin() {
+	atomic.AddInt64(&r.outstanding, 1)
+}
+
+// end records the outcome of a call to the replica. A replica that cannot be
+// reached, or that fails several calls in a row, is marked unhealthy until a
+// call or probe to it succeeds again. Single slow or failed calls are left to
+// the circuit breaker; calls cancelled by the caller, such as the losing side
+// of a hedge, do not count.
+func (r *replica) end(latency time.Duration, err error) {
+	atomic.AddInt64(&r.outstanding, -1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if status.Code(err) == codes.Canceled {
+		return
+	}
+	r.requests++
+	if err != nil {
+		r.failures++
+		r.consecutiveFailures++
+		r.lastError = err
+		if status.Code(err) == codes.Unavailable || r.consecutiveFailures >= replicaFailureThreshold {
+			r.healthy = false
+		}
+		return
+	}
+	r.setHealthyLocked()
+	ms := float64(latency) / float64(time.Millisecond)
+	if r.ewmaMs == 0 {
+		r.ewmaMs = ms
+	} else {
+		r.ewmaMs = ewmaAlpha*ms + (1-ewmaAlpha)*r.ewmaMs
+	}
+}
+
+// setHealth records the result of a health probe against the replica
+func (r *replica) setHealth(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.healthy = false
+		r.lastError = err
+		return
+	}
+	r.setHealthyLocked()
+}
+
+// setHealthyLocked marks the replica healthy; r.mu must be held
+func (r *replica) setHealthyLocked() {
+	r.healthy = true
+	r.lastError = nil
+	r.consecutiveFailures = 0
+}
+
+// isHealthy returns whether the replica is currently considered reachable
+func (r *replica) isHealthy() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.healthy
+}
+
+// latencyScore returns the replica's latency moving average weighted by its
+// in-flight calls. Replicas without samples score zero so they are tried.
+func (r *replica) latencyScore() float64 {
+	r.mu.Lock()
+	ewma := r.ewmaMs
+	r.mu.Unlock()
+	return ewma * float64(atomic.LoadInt64(&r.outstanding)+1)
+}
+
+// info returns a snapshot of the replica state
+func (r *replica) info() ReplicaInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info := ReplicaInfo{
+		Address:       r.address,
+		Healthy:       r.healthy,
+		Outstanding:   atomic.LoadInt64(&r.outstanding),
+		LatencyEWMAMs: r.ewmaMs,
+		Requests:      r.requests,
+		Failures:      r.failures,
+	}
+	if r.lastError != nil {
+		info.LastError = r.lastError.Error()
+	}
+	return info
+}
+
+// pickReplica returns the replica for the next call using the endpoint's
+// load balancing strategy, or nil if there are none. Unhealthy replicas are
+// skipped unless every replica is unhealthy.
+func (c *Client) pickReplica() *replica {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.replicas) == 0 {
+		return nil
+	}
+
+	candidates := make([]*replica, 0, len(c.replicas))
+	for _, r := range c.replicas {
+		if r.isHealthy() {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = c.replicas
+	}
+
+	// Start from a rotating offset so ties are spread across replicas
+	start := int((atomic.AddUint64(&c.next, 1) - 1) % uint64(len(candidates)))
+	switch c.config.GetLoadBalancing() {
+	case LoadBalanceLeastOutstanding:
+		return pickMin(candidates, start, func(r *replica) float64 {
+			return float64(atomic.LoadInt64(&r.outstanding))
+		})
+	case LoadBalanceLatencyEWMA:
+		return pickMin(candidates, start, (*replica).latencyScore)
+	default:
+		return candidates[start]
+	}
+}
+
+// pickMin returns the replica with the lowest score, scanning from start
+func pickMin(replicas []*replica, start int, score func(*replica) float64) *replica {
+	best := replicas[start]
+	bestScore := score(best)
+	for i := 1; i < len(replicas); i++ {
+		r := replicas[(start+i)%len(replicas)]
+		if s := score(r); s < bestScore {
+			best, bestScore = r, s
+		}
+	}
+	return best
+}
+
+// hasHealthyReplica reports whether any replica is currently considered reachable
+func (c *Client) hasHealthyReplica() bool {
+	for _, r := range c.replicaSet() {
+		if r.isHealthy() {
+			return true
+		}
+	}
+	return false
+}
+
+// replicaSet returns a snapshot of the endpoint's replicas
+func (c *Client) replicaSet() []*replica {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	replicas := make([]*replica, len(c.replicas))
+	copy(replicas, c.replicas)
+	return replicas
+}
+
+// Replicas returns the state of each of the endpoint's replicas
+func (c *Client) Replicas() []ReplicaInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	infos := make([]ReplicaInfo, 0, len(c.replicas))
+	for _, r := range c.replicas {
+		infos = append(infos, r.info())
+	}
+	return infos
+}
+
+// dialReplica creates a connection to one endpoint instance
+func dialReplica(address string, opts []grpc.DialOption, streaming *StreamingConfig) (*replica, error) {
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	r := &replica{
+		address:   address,
+		conn:      conn,
+		rtbClient: pb.NewRTBExtensionPointClient(conn),
+		healthy:   true,
+	}
+	if streaming != nil {
+		r.stream = newMutationStream(address, r.rtbClient, streaming)
+	}
+	return r, nil
+}
+
+// close closes the replica's stream and connection
+func (r *replica) close() error {
+	if r.stream != nil {
+		r.stream.close()
+	}
+	return r.conn.Close()
+}
@@ -23,50 +23,87 @@ import (
 	"crypto/x509"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/validate"
 	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
-// Client wraps a GRPC connection to a federated endpoint
+// latencyWindow is the number of recent call latencies kept for hedging
+const latencyWindow = 100
+
+// minHedgeSamples is the number of latencies needed before the hedge percentile is used
+const minHedgeSamples = 20
+
+// Client wraps the GRPC connections to a federated endpoint.
+// An endpoint has one connection per instance; requests are balanced across them.
 type Client struct {
 	config    EndpointConfig
-	conn      *grpc.ClientConn
-	rtbClient pb.RTBExtensionPointClient
+	defaults  *EndpointDefaults
+	dialOpts  []grpc.DialOption
+	replicas  []*replica
+	next      uint64
 	mu        sync.RWMutex
 	healthy   bool
 	lastError error
 	lastCheck time.Time
+	dropped   *validate.Counter
+	latencies []time.Duration
+	probes    []ProbeResult
+	stopProbe chan struct{}
+	probeDone chan struct{}
+	breaker   *circuitBreaker
+	guard     *intentGuard
+	redaction fieldTree
+	admission *AdmissionResult
+
+	stopDiscovery context.CancelFunc
+	discoveryDone chan struct{}
 }
 
 // ClientPool manages connections to multiple federated endpoints
 type ClientPool struct {
-	config  *Config
-	clients map[string]*Client
-	mu      sync.RWMutex
+	config     *Config
+	clients    map[string]*Client
+	admissions map[string]*AdmissionResult
+	mu         sync.RWMutex
 }
 
 // NewClientPool creates a new client pool from configuration
 func NewClientPool(config *Config) (*ClientPool, error) {
 	pool := &ClientPool{
-		config:  config,
-		clients: make(map[string]*Client),
+		config:     config,
+		clients:    make(map[string]*Client),
+		admissions: make(map[string]*AdmissionResult),
 	}
 
-	// Initialize clients for all enabled endpoints
+	// Initialize clients for all enabled endpoints that pass admission
 	for _, ep := range config.GetEnabledEndpoints() {
+		admission := admit(config.Admission, ep)
+		if admission != nil {
+			pool.admissions[ep.Name] = admission
+			if admission.Status == AdmissionRefused {
+				continue
+			}
+		}
 		client, err := NewClient(ep, config.Defaults)
 		if err != nil {
 			log.Printf("[Federation] Failed to create client for endpoint '%s': %v", ep.Name, err)
 			continue
 		}
+		client.admission = admission
 		pool.clients[ep.Name] = client
-		log.Printf("[Federation] Initialized client for endpoint '%s' at %s", ep.Name, ep.Address)
+		log.Printf("[Federation] Initialized client for endpoint '%s' at %s", ep.Name, ep.Target())
 	}
 
 	return pool, nil
@@ -79,6 +116,8 @@ func NewClient(config EndpointConfig, defaults *EndpointDefaults) (*Client, erro
 			grpc.MaxCallRecvMsgSize(10 * 1024 * 1024), // 10MB
 			grpc.MaxCallSendMsgSize(10 * 1024 * 1024),
 		),
+		// Propagate trace context to the endpoint over gRPC metadata
+		grpc.WithStatsHandler(tracing.ClientHandler()),
 	}
 
 	// Configure TLS
@@ -97,19 +136,42 @@ func NewClient(config EndpointConfig, defaults *EndpointDefaults) (*Client, erro
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	// Connect
-	conn, err := grpc.NewClient(config.Address, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w", config.Address, err)
+	client := &Client{
+		config:   config,
+		defaults: defaults,
+		dialOpts: opts,
+		healthy:  true,
+		dropped:  validate.NewCounter(),
 	}
 
-	client := &Client{
-		config:    config,
-		conn:      conn,
-		rtbClient: pb.NewRTBExtensionPointClient(conn),
-		healthy:   true,
+	if config.Redaction != nil {
+		tree, err := config.Redaction.compile(config.ApplicableIntents)
+		if err != nil {
+			return nil, err
+		}
+		client.redaction = tree
 	}
 
+	// Connect to the configured replicas, or discover instances
+	if config.Discovery == nil {
+		for _, address := range config.StaticAddresses() {
+			r, err := dialReplica(address, opts, config.GetStreaming(defaults))
+			if err != nil {
+				for _, r := range client.replicas {
+					r.close()
+				}
+				return nil, err
+			}
+			client.replicas = append(client.replicas, r)
+		}
+	}
+	if cb := config.GetCircuitBreaker(defaults); cb != nil {
+		client.breaker = newCircuitBreaker(config.Name, *cb)
+	}
+	client.guard = newIntentGuard(config.Name, config.GetIntentEnforcement(defaults))
+	client.startDiscovery()
+	client.startProber()
+
 	return client, nil
 }
 
@@ -148,30 +210,252 @@ func buildTLSCredentials(config *TLSConfig) (credentials.TransportCredentials, e
 	return credentials.NewTLS(tlsConfig), nil
 }
 
-// GetMutations calls the RTBExtensionPoint GRPC service
+// GetMutations calls the RTBExtensionPoint GRPC service.
+// Mutations that do not conform to the intent, operation and payload rules
+// are dropped from the response.
 func (c *Client) GetMutations(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	resp, _, err := c.getMutations(ctx, req)
+	return resp, err
+}
+
+// getMutations calls the endpoint and returns the conforming response along
+// with the violations for any mutations that were dropped
+func (c *Client) getMutations(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, []validate.Violation, error) {
 	c.mu.RLock()
 	if !c.healthy {
 		c.mu.RUnlock()
-		return nil, fmt.Errorf("endpoint '%s' is unhealthy: %v", c.config.Name, c.lastError)
+		return nil, nil, fmt.Errorf("endpoint '%s' is unhealthy: %v", c.config.Name, c.lastError)
 	}
 	c.mu.RUnlock()
 
 	// Apply timeout
-	timeout := time.Duration(c.config.GetTimeoutMs(nil)) * time.Millisecond
+	startTime := time.Now()
+	timeout := time.Duration(c.config.GetTimeoutMs(c.defaults)) * time.Millisecond
+	callerCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if c.rtbClient == nil {
-		return nil, fmt.Errorf("RTBExtensionPoint client not initialized")
+	if len(c.Instances()) == 0 {
+		return nil, nil, fmt.Errorf("endpoint '%s' has no instances", c.config.Name)
+	}
+
+	// Retries may only use what is left of the request's tmax
+	budget, _ := ctx.Deadline()
+	if tmax := req.GetTmax(); tmax > 0 {
+		if tmaxDeadline := startTime.Add(time.Duration(tmax) * time.Millisecond); tmaxDeadline.Before(budget) {
+			budget = tmaxDeadline
+		}
+	}
+
+	// The circuit breaker rejects calls while open and limits half-open trials
+	if c.breaker != nil && !c.breaker.allow() {
+		return nil, nil, fmt.Errorf("endpoint '%s' circuit is open", c.config.Name)
+	}
+
+	maxRetries := c.config.GetMaxRetries(c.defaults)
+	var resp *pb.RTBResponse
+	var err error
+	for attempt := 0; ; attempt++ {
+		attemptStart := time.Now()
+		resp, err = c.call(ctx, req)
+		if err == nil {
+			c.recordLatency(time.Since(attemptStart))
+			if c.breaker != nil {
+				c.breaker.record(true)
+			}
+			break
+		}
+
+		// Only retry if another attempt is likely to finish within the budget
+		// after backing off
+		elapsed := time.Since(attemptStart)
+		backoff := retryBackoff(attempt)
+		if attempt >= maxRetries || !isRetryable(err) || time.Until(budget) < elapsed+backoff {
+			c.recordFailure(callerCtx, ctx, err)
+			return nil, nil, err
+		}
+		log.Printf("[Federation] Endpoint '%s' attempt %d failed, retrying in %v: %v", c.config.Name, attempt+1, backoff, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			c.recordFailure(callerCtx, ctx, err)
+			return nil, nil, err
+		case <-timer.C:
+		}
+	}
+
+	// Drop non-conforming mutations before they reach the orchestrator
+	mutations, violations := validate.Filter(resp.GetMutations())
+	if len(violations) > 0 {
+		c.dropped.Add(violations)
+		for _, v := range violations {
+			log.Printf("[Federation] Endpoint '%s' sent non-conforming %s", c.config.Name, v)
+		}
+	}
+
+	// Drop mutations for intents the endpoint is not configured for
+	mutations, outOfScope := c.enforceIntents(mutations)
+	violations = append(violations, outOfScope...)
+	if len(violations) > 0 {
+		resp.Mutations = mutations
+	}
+	return resp, violations, nil
+}
+
+// call makes a single attempt, hedged with a second request if hedging is enabled
+func (c *Client) call(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	delay, hedge := c.hedgeDelay()
+	if !hedge {
+		return c.callReplica(ctx, req)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type callResult struct {
+		resp *pb.RTBResponse
+		err  error
+	}
+	results := make(chan callResult, 2)
+	send := func() {
+		resp, err := c.callReplica(ctx, req)
+		results <- callResult{resp: resp, err: err}
 	}
 
-	resp, err := c.rtbClient.GetMutations(ctx, req)
-	if err != nil {
+	go send()
+	inflight := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	// Take the first successful response; fail only when no request is left in flight
+	for {
+		select {
+		case r := <-results:
+			inflight--
+			if r.err == nil || inflight == 0 {
+				return r.resp, r.err
+			}
+		case <-timer.C:
+			if inflight == 1 {
+				log.Printf("[Federation] Endpoint '%s' sending hedged request after %v", c.config.Name, delay)
+				inflight++
+				go send()
+			}
+		}
+	}
+}
+
+// callReplica sends a request to the replica chosen by the load balancer
+func (c *Client) callReplica(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	r := c.pickReplica()
+	if r == nil {
+		return nil, fmt.Errorf("endpoint '%s' has no instances", c.config.Name)
+	}
+	start := time.Now()
+	r.begin()
+	resp, err := r.getMutations(ctx, req)
+	r.end(time.Since(start), err)
+	return resp, err
+}
+
+// hedgeDelay returns how long to wait before sending a hedged request.
+// Returns false if hedging is disabled or there is not yet enough latency data.
+func (c *Client) hedgeDelay() (time.Duration, bool) {
+	hedging := c.config.GetHedging(c.defaults)
+	if hedging == nil {
+		return 0, false
+	}
+	minDelay := time.Duration(hedging.MinDelayMs) * time.Millisecond
+
+	c.mu.RLock()
+	samples := make([]time.Duration, len(c.latencies))
+	copy(samples, c.latencies)
+	c.mu.RUnlock()
+
+	if len(samples) < minHedgeSamples {
+		return minDelay, minDelay > 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	index := int(float64(len(samples)-1) * hedging.GetPercentile() / 100)
+	delay := samples[index]
+	if delay < minDelay {
+		delay = minDelay
+	}
+	return delay, true
+}
+
+// recordLatency adds a successful call latency to the sliding window
+func (c *Client) recordLatency(latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latencies = append(c.latencies, latency)
+	if len(c.latencies) > latencyWindow {
+		c.latencies = c.latencies[len(c.latencies)-latencyWindow:]
+	}
+}
+
+// isRetryable reports whether a failed call may succeed if retried
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// endpointFault reports whether a failed call reflects on the endpoint:
+// transport and server errors, or the endpoint's own timeout expiring.
+// Rejected requests, and calls the caller's context cancelled or timed out,
+// say nothing about its health.
+func endpointFault(callerCtx, ctx context.Context, err error) bool {
+	if callerCtx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Internal, codes.ResourceExhausted:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == context.DeadlineExceeded
+	default:
+		return false
+	}
+}
+
+// Retry backoff doubles from retryBaseBackoff up to retryMaxBackoff, with
+// jitter so that callers retrying the same endpoint spread out
+const (
+	retryBaseBackoff = 5 * time.Millisecond
+	retryMaxBackoff  = 40 * time.Millisecond
+)
+
+// retryBackoff returns the delay before the retry following the given attempt,
+// chosen at random between half and all of the exponential backoff
+func retryBackoff(attempt int) time.Duration {
+	backoff := retryMaxBackoff
+	if attempt < 3 {
+		backoff = retryBaseBackoff << attempt
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// recordFailure counts a failed call towards the circuit breaker or, without
+// one, marks the endpoint unhealthy once none of its replicas is healthy.
+// Failures that are not the endpoint's fault are not counted.
+func (c *Client) recordFailure(callerCtx, ctx context.Context, err error) {
+	if !endpointFault(callerCtx, ctx, err) {
+		if c.breaker != nil {
+			c.breaker.release()
+		}
+		return
+	}
+	if c.breaker != nil {
+		c.breaker.record(false)
+	} else if !c.hasHealthyReplica() {
 		c.markUnhealthy(err)
-		return nil, err
 	}
-	return resp, nil
 }
 
 // markUnhealthy marks the client as unhealthy
@@ -203,17 +487,111 @@ func (c *Client) IsHealthy() bool {
 	return c.healthy
 }
 
+// IsAvailable returns whether the client passed admission, is not disabled by
+// intent enforcement, is healthy, has instances and its circuit admits calls
+func (c *Client) IsAvailable() bool {
+	if !c.admission.Admitted() || c.DisabledReason() != "" || !c.IsHealthy() || len(c.Instances()) == 0 {
+		return false
+	}
+	return c.breaker == nil || c.breaker.State() != CircuitOpen
+}
+
+// CircuitState returns the circuit breaker state, or "" if no breaker is configured
+func (c *Client) CircuitState() string {
+	if c.breaker == nil {
+		return ""
+	}
+	return c.breaker.State()
+}
+
+// DroppedMutations returns the counter of non-conforming mutations dropped from this endpoint
+func (c *Client) DroppedMutations() *validate.Counter {
+	return c.dropped
+}
+
 // Config returns the endpoint configuration
 func (c *Client) Config() EndpointConfig {
 	return c.config
 }
 
-// Close closes the client connection
+// Close stops health checking and discovery and closes all instance connections
 func (c *Client) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
+	c.stopProber()
+	c.stopDiscoveryLoop()
+
+	c.mu.Lock()
+	replicas := c.replicas
+	c.replicas = nil
+	c.mu.Unlock()
+
+	var lastErr error
+	for _, r := range replicas {
+		if err := r.close(); err != nil {
+			lastErr = err
+		}
 	}
-	return nil
+	return lastErr
+}
+
+// setAddresses reconciles the instance connections with the given addresses.
+// Existing connections are kept; connections to removed instances are closed
+// once in-flight calls have had time to finish.
+func (c *Client) setAddresses(addresses []string) {
+	c.mu.Lock()
+	current := make(map[string]*replica, len(c.replicas))
+	for _, r := range c.replicas {
+		current[r.address] = r
+	}
+	var next []*replica
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		if r, ok := current[address]; ok {
+			next = append(next, r)
+			continue
+		}
+		r, err := dialReplica(address, c.dialOpts, c.config.GetStreaming(c.defaults))
+		if err != nil {
+			log.Printf("[Federation] Endpoint '%s' skipping instance: %v", c.config.Name, err)
+			continue
+		}
+		next = append(next, r)
+	}
+	var removed []*replica
+	for address, r := range current {
+		if !seen[address] {
+			removed = append(removed, r)
+		}
+	}
+	changed := len(removed) > 0 || len(next) != len(current)
+	c.replicas = next
+	c.mu.Unlock()
+
+	if changed {
+		log.Printf("[Federation] Endpoint '%s' instances updated: %v", c.config.Name, addresses)
+	}
+	if len(removed) > 0 {
+		grace := time.Duration(c.config.GetTimeoutMs(c.defaults)) * time.Millisecond
+		time.AfterFunc(grace, func() {
+			for _, r := range removed {
+				r.close()
+			}
+		})
+	}
+}
+
+// Instances returns the addresses of the endpoint's current instances
+func (c *Client) Instances() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	addresses := make([]string, 0, len(c.replicas))
+	for _, r := range c.replicas {
+		addresses = append(addresses, r.address)
+	}
+	return addresses
 }
 
 // GetClient returns a client by endpoint name
@@ -223,6 +601,14 @@ func (p *ClientPool) GetClient(name string) *Client {
 	return p.clients[name]
 }
 
+// GetAdmission returns the admission result for an endpoint, or nil if it
+// was not checked
+func (p *ClientPool) GetAdmission(name string) *AdmissionResult {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.admissions[name]
+}
+
 // GetClients returns all clients
 func (p *ClientPool) GetClients() []*Client {
 	p.mu.RLock()
@@ -240,20 +626,20 @@ func (p *ClientPool) GetClientsByIntent(intent string) []*Client {
 	defer p.mu.RUnlock()
 	var clients []*Client
 	for _, c := range p.clients {
-		if c.config.HasIntent(intent) && c.IsHealthy() {
+		if c.config.HasIntent(intent) && c.IsAvailable() {
 			clients = append(clients, c)
 		}
 	}
 	return clients
 }
 
-// GetHealthyClients returns all healthy clients
+// GetHealthyClients returns all healthy clients whose circuit is not open
 func (p *ClientPool) GetHealthyClients() []*Client {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 	var clients []*Client
 	for _, c := range p.clients {
-		if c.IsHealthy() {
+		if c.IsAvailable() {
 			clients = append(clients, c)
 		}
 	}
@@ -273,3 +659,137 @@ func (p *ClientPool) Close() error {
 	}
 	return lastErr
 }
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// circuitBreaker tracks call outcomes for an endpoint and stops calls while it is failing
+type circuitBreaker struct {
+	name      string
+	config    CircuitBreakerConfig
+	mu        sync.Mutex
+	state     string
+	failures  int
+	window    []bool
+	openedAt  time.Time
+	trials    int
+	successes int
+}
+
+// newCircuitBreaker creates a closed circuit breaker
+func newCircuitBreaker(name string, config CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{
+		name:   name,
+		config: config.withDefaults(),
+		state:  CircuitClosed,
+	}
+}
+
+// allow reports whether a call may proceed. In half-open state only the
+// configured number of trial calls are let through.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.checkCooldown()
+
+	switch b.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if b.trials < b.config.HalfOpenRequests {
+			b.trials++
+			return true
+		}
+	}
+	return false
+}
+
+// record registers the outcome of a call that was allowed
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitClosed:
+		b.window = append(b.window, success)
+		if len(b.window) > b.config.WindowSize {
+			b.window = b.window[len(b.window)-b.config.WindowSize:]
+		}
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.ConsecutiveFailures {
+			b.transition(CircuitOpen, fmt.Sprintf("%d consecutive failures", b.failures))
+		} else if rate := b.errorRate(); len(b.window) >= b.config.MinRequests && rate >= b.config.ErrorRatePercent {
+			b.transition(CircuitOpen, fmt.Sprintf("error rate %.1f%%", rate))
+		}
+	case CircuitHalfOpen:
+		if !success {
+			b.transition(CircuitOpen, "trial request failed")
+			return
+		}
+		b.successes++
+		if b.successes >= b.config.HalfOpenRequests {
+			b.transition(CircuitClosed, "trial requests succeeded")
+		}
+	}
+}
+
+// release gives back a half-open trial whose call failed for reasons that
+// say nothing about the endpoint, so another call can take its place
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen && b.trials > 0 {
+		b.trials--
+	}
+}
+
+// State returns the current state, moving an open circuit to half-open once the cool-down has passed
+func (b *circuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.checkCooldown()
+	return b.state
+}
+
+// checkCooldown moves an open circuit to half-open after the cool-down. Caller must hold mu.
+func (b *circuitBreaker) checkCooldown() {
+	cooldown := time.Duration(b.config.CooldownMs) * time.Millisecond
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= cooldown {
+		b.transition(CircuitHalfOpen, "cool-down elapsed")
+	}
+}
+
+// transition changes state and resets the counters. Caller must hold mu.
+func (b *circuitBreaker) transition(state, reason string) {
+	log.Printf("[Federation] Endpoint '%s' circuit %s -> %s: %s", b.name, b.state, state, reason)
+	b.state = state
+	b.failures = 0
+	b.window = nil
+	b.trials = 0
+	b.successes = 0
+	if state == CircuitOpen {
+		b.openedAt = time.Now()
+	}
+}
+
+// errorRate returns the percentage of failed calls in the window. Caller must hold mu.
+func (b *circuitBreaker) errorRate() float64 {
+	if len(b.window) == 0 {
+		return 0
+	}
+	failed := 0
+	for _, ok := range b.window {
+		if !ok {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(b.window)) * 100
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -37,6 +38,31 @@ type Config struct {
 
 	// Defaults for all endpoints
 	Defaults *EndpointDefaults `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// ConflictResolution maps an intent name to the strategy used to merge
+	// contradictory mutations from different endpoints. Intents without an
+	// entry keep every proposed mutation.
+	ConflictResolution map[string]string `json:"conflict_resolution,omitempty" yaml:"conflict_resolution,omitempty"`
+
+	// Pipeline applies the accepted mutations of each priority group to the
+	// bid request before the next group is called
+	Pipeline bool `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+
+	// Admission checks each endpoint's agent manifest before it receives traffic
+	Admission *AdmissionConfig `json:"admission,omitempty" yaml:"admission,omitempty"`
+
+	// Originator identifies this orchestrator in requests sent to endpoints.
+	// If not set the caller's originator is forwarded unchanged.
+	Originator *OriginatorConfig `json:"originator,omitempty" yaml:"originator,omitempty"`
+}
+
+// OriginatorConfig is the originator stamped on outgoing requests
+type OriginatorConfig struct {
+	// Type is the originator type (TYPE_PUBLISHER, TYPE_SSP, TYPE_EXCHANGE or TYPE_DSP)
+	Type string `json:"type" yaml:"type"`
+
+	// ID is the originator's identifier
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
 }
 
 // EndpointDefaults contains default settings for endpoints
@@ -47,6 +73,18 @@ type EndpointDefaults struct {
 	// MaxRetries is the default number of retries
 	MaxRetries int `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
 
+	// Hedging is the default hedged request configuration
+	Hedging *HedgingConfig `json:"hedging,omitempty" yaml:"hedging,omitempty"`
+
+	// Streaming is the default StreamMutations configuration
+	Streaming *StreamingConfig `json:"streaming,omitempty" yaml:"streaming,omitempty"`
+
+	// CircuitBreaker is the default circuit breaker configuration
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker,omitempty" yaml:"circuit_breaker,omitempty"`
+
+	// IntentEnforcement is the default policy for endpoints returning out-of-scope intents
+	IntentEnforcement *IntentEnforcementConfig `json:"intent_enforcement,omitempty" yaml:"intent_enforcement,omitempty"`
+
 	// TLS configuration
 	TLS *TLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
 }
@@ -59,6 +97,21 @@ type EndpointConfig struct {
 	// Address is the GRPC address (host:port)
 	Address string `json:"address" yaml:"address"`
 
+	// Addresses lists further replicas of the endpoint; requests are balanced
+	// across Address and Addresses
+	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+
+	// LoadBalancing selects how requests are spread across replicas:
+	// "round_robin" (default), "least_outstanding" or "latency_ewma"
+	LoadBalancing string `json:"load_balancing,omitempty" yaml:"load_balancing,omitempty"`
+
+	// Discovery resolves the endpoint's instances dynamically instead of using Address
+	Discovery *DiscoveryConfig `json:"discovery,omitempty" yaml:"discovery,omitempty"`
+
+	// ManifestURL is the HTTP URL of the endpoint's agent manifest, such as
+	// http://segments:8080/manifest (required when admission is configured)
+	ManifestURL string `json:"manifest_url,omitempty" yaml:"manifest_url,omitempty"`
+
 	// Description provides human-readable info about this endpoint
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
@@ -70,7 +123,8 @@ type EndpointConfig struct {
 	ApplicableIntents []string `json:"applicable_intents" yaml:"applicable_intents"`
 
 	// Priority determines call order (lower = higher priority, called first)
-	// Endpoints with same priority may be called in parallel
+	// Endpoints with same priority may be called in parallel. In pipeline mode
+	// each priority group sees the mutations accepted from earlier groups.
 	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
 
 	// Enabled determines if this endpoint is active
@@ -82,9 +136,24 @@ type EndpointConfig struct {
 	// MaxRetries overrides the default retries for this endpoint
 	MaxRetries int `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
 
+	// Hedging overrides the default hedged request configuration for this endpoint
+	Hedging *HedgingConfig `json:"hedging,omitempty" yaml:"hedging,omitempty"`
+
+	// Streaming overrides the default StreamMutations configuration for this endpoint
+	Streaming *StreamingConfig `json:"streaming,omitempty" yaml:"streaming,omitempty"`
+
+	// CircuitBreaker overrides the default circuit breaker configuration for this endpoint
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker,omitempty" yaml:"circuit_breaker,omitempty"`
+
+	// IntentEnforcement overrides the default policy for out-of-scope intents for this endpoint
+	IntentEnforcement *IntentEnforcementConfig `json:"intent_enforcement,omitempty" yaml:"intent_enforcement,omitempty"`
+
 	// TLS configuration for this endpoint
 	TLS *TLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
 
+	// Redaction limits the request fields sent to this endpoint; if unset the full request is sent
+	Redaction *RedactionConfig `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+
 	// Metadata is arbitrary key-value data for this endpoint
 	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 
@@ -113,6 +182,168 @@ type TLSConfig struct {
 	ServerName string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
 }
 
+// DiscoveryConfig describes where the instances of an endpoint are discovered.
+// Requests are load-balanced across the discovered instances.
+type DiscoveryConfig struct {
+	// Type is the discovery source: "dns" (A/AAAA records), "srv" (SRV records) or "file"
+	Type string `json:"type" yaml:"type"`
+
+	// Name is the DNS name to resolve (dns and srv)
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Port is combined with each resolved host (dns only)
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+
+	// Path is the JSON file listing instance addresses (file only)
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// RefreshSeconds is the time between lookups or file checks (default: 10)
+	RefreshSeconds int `json:"refresh_seconds,omitempty" yaml:"refresh_seconds,omitempty"`
+}
+
+// GetRefresh returns the time between discovery refreshes (default: 10s)
+func (d *DiscoveryConfig) GetRefresh() time.Duration {
+	if d.RefreshSeconds > 0 {
+		return time.Duration(d.RefreshSeconds) * time.Second
+	}
+	return 10 * time.Second
+}
+
+// String describes the discovery source
+func (d *DiscoveryConfig) String() string {
+	switch d.Type {
+	case DiscoveryDNS:
+		return fmt.Sprintf("dns:%s:%d", d.Name, d.Port)
+	case DiscoveryFile:
+		return "file:" + d.Path
+	default:
+		return d.Type + ":" + d.Name
+	}
+}
+
+// validate checks the discovery source settings
+func (d *DiscoveryConfig) validate() error {
+	switch d.Type {
+	case DiscoveryDNS:
+		if d.Name == "" || d.Port <= 0 {
+			return fmt.Errorf("dns discovery requires name and port")
+		}
+	case DiscoverySRV:
+		if d.Name == "" {
+			return fmt.Errorf("srv discovery requires name")
+		}
+	case DiscoveryFile:
+		if d.Path == "" {
+			return fmt.Errorf("file discovery requires path")
+		}
+	default:
+		return fmt.Errorf("invalid discovery type '%s'", d.Type)
+	}
+	return nil
+}
+
+// Target describes where the endpoint is reached: its addresses or discovery source
+func (e *EndpointConfig) Target() string {
+	if e.Discovery != nil {
+		return e.Discovery.String()
+	}
+	return strings.Join(e.StaticAddresses(), ",")
+}
+
+// StaticAddresses returns the configured replica addresses without duplicates
+func (e *EndpointConfig) StaticAddresses() []string {
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, address := range append([]string{e.Address}, e.Addresses...) {
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// GetLoadBalancing returns the replica balancing strategy
+func (e *EndpointConfig) GetLoadBalancing() string {
+	if e.LoadBalancing == "" {
+		return LoadBalanceRoundRobin
+	}
+	return e.LoadBalancing
+}
+
+// HedgingConfig contains hedged request settings. When enabled, a second
+// attempt is sent if the first has not answered after the given latency
+// percentile, and whichever response arrives first is used.
+type HedgingConfig struct {
+	// Enabled determines if hedged requests are sent
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Percentile of recent latencies after which the hedge is sent (default: 95)
+	Percentile float64 `json:"percentile,omitempty" yaml:"percentile,omitempty"`
+
+	// MinDelayMs is the minimum hedge delay, also used until enough latencies are recorded.
+	// If zero, no hedge is sent until enough latencies are recorded.
+	MinDelayMs int `json:"min_delay_ms,omitempty" yaml:"min_delay_ms,omitempty"`
+}
+
+// GetPercentile returns the hedge percentile (default: 95)
+func (h *HedgingConfig) GetPercentile() float64 {
+	if h.Percentile > 0 {
+		return h.Percentile
+	}
+	return 95
+}
+
+// CircuitBreakerConfig contains circuit breaker settings. The circuit opens
+// after too many consecutive failures or too high an error rate, rejects calls
+// for the cool-down period, then lets trial requests through in half-open state.
+type CircuitBreakerConfig struct {
+	// Enabled determines if the circuit breaker is active
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// ConsecutiveFailures opens the circuit after this many failures in a row (default: 5)
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty" yaml:"consecutive_failures,omitempty"`
+
+	// ErrorRatePercent opens the circuit when the error rate in the window reaches this value (default: 50)
+	ErrorRatePercent float64 `json:"error_rate_percent,omitempty" yaml:"error_rate_percent,omitempty"`
+
+	// WindowSize is the number of recent calls used for the error rate (default: 100)
+	WindowSize int `json:"window_size,omitempty" yaml:"window_size,omitempty"`
+
+	// MinRequests is the number of calls in the window before the error rate applies (default: 20)
+	MinRequests int `json:"min_requests,omitempty" yaml:"min_requests,omitempty"`
+
+	// CooldownMs is how long the circuit stays open before trial requests (default: 5000)
+	CooldownMs int `json:"cooldown_ms,omitempty" yaml:"cooldown_ms,omitempty"`
+
+	// HalfOpenRequests is the number of trial requests that must succeed to close the circuit (default: 1)
+	HalfOpenRequests int `json:"half_open_requests,omitempty" yaml:"half_open_requests,omitempty"`
+}
+
+// withDefaults returns a copy of the configuration with unset values defaulted
+func (b CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if b.ConsecutiveFailures <= 0 {
+		b.ConsecutiveFailures = 5
+	}
+	if b.ErrorRatePercent <= 0 {
+		b.ErrorRatePercent = 50
+	}
+	if b.WindowSize <= 0 {
+		b.WindowSize = 100
+	}
+	if b.MinRequests <= 0 {
+		b.MinRequests = 20
+	}
+	if b.CooldownMs <= 0 {
+		b.CooldownMs = 5000
+	}
+	if b.HalfOpenRequests <= 0 {
+		b.HalfOpenRequests = 1
+	}
+	return b
+}
+
 // HealthCheckConfig contains health check settings
 type HealthCheckConfig struct {
 	// Enabled determines if health checking is active
@@ -123,6 +354,40 @@ type HealthCheckConfig struct {
 
 	// TimeoutSeconds is the health check timeout
 	TimeoutSeconds int `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"`
+
+	// Method is the probe type: "grpc" for the standard gRPC health protocol
+	// or "ping" for a synthetic GetMutations call (default: grpc)
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+}
+
+// Health check probe methods
+const (
+	HealthCheckGRPC = "grpc"
+	HealthCheckPing = "ping"
+)
+
+// GetInterval returns the time between probes (default: 10s)
+func (h *HealthCheckConfig) GetInterval() time.Duration {
+	if h.IntervalSeconds > 0 {
+		return time.Duration(h.IntervalSeconds) * time.Second
+	}
+	return 10 * time.Second
+}
+
+// GetTimeout returns the probe timeout (default: 2s)
+func (h *HealthCheckConfig) GetTimeout() time.Duration {
+	if h.TimeoutSeconds > 0 {
+		return time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	return 2 * time.Second
+}
+
+// GetMethod returns the probe method (default: grpc)
+func (h *HealthCheckConfig) GetMethod() string {
+	if h.Method == "" {
+		return HealthCheckGRPC
+	}
+	return strings.ToLower(h.Method)
 }
 
 // IsEnabled returns whether this endpoint is enabled (default: true)
@@ -163,6 +428,30 @@ func (e *EndpointConfig) GetMaxRetries(defaults *EndpointDefaults) int {
 	return 0
 }
 
+// GetHedging returns the hedging configuration, or nil if hedging is disabled
+func (e *EndpointConfig) GetHedging(defaults *EndpointDefaults) *HedgingConfig {
+	hedging := e.Hedging
+	if hedging == nil && defaults != nil {
+		hedging = defaults.Hedging
+	}
+	if hedging == nil || !hedging.Enabled {
+		return nil
+	}
+	return hedging
+}
+
+// GetCircuitBreaker returns the circuit breaker configuration, or nil if it is disabled
+func (e *EndpointConfig) GetCircuitBreaker(defaults *EndpointDefaults) *CircuitBreakerConfig {
+	breaker := e.CircuitBreaker
+	if breaker == nil && defaults != nil {
+		breaker = defaults.CircuitBreaker
+	}
+	if breaker == nil || !breaker.Enabled {
+		return nil
+	}
+	return breaker
+}
+
 // HasIntent checks if this endpoint accepts the given intent
 func (e *EndpointConfig) HasIntent(intent string) bool {
 	if len(e.ApplicableIntents) == 0 {
@@ -218,6 +507,25 @@ func ParseConfig(data []byte, filename string) (*Config, error) {
 
 // Validate checks the configuration for errors
 func (c *Config) Validate() error {
+	if c.Defaults != nil && c.Defaults.Hedging != nil && (c.Defaults.Hedging.Percentile < 0 || c.Defaults.Hedging.Percentile > 100) {
+		return fmt.Errorf("defaults: hedging percentile must be between 0 and 100")
+	}
+	if c.Defaults != nil && c.Defaults.Streaming != nil && c.Defaults.Streaming.MaxInFlight < 0 {
+		return fmt.Errorf("defaults: streaming max_in_flight must not be negative")
+	}
+
+	if c.Admission != nil {
+		if err := c.Admission.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Originator != nil {
+		if err := c.Originator.validate(); err != nil {
+			return err
+		}
+	}
+
 	seen := make(map[string]bool)
 	for i, ep := range c.Endpoints {
 		if ep.Name == "" {
@@ -228,8 +536,25 @@ func (c *Config) Validate() error {
 		}
 		seen[ep.Name] = true
 
-		if ep.Address == "" {
-			return fmt.Errorf("endpoint '%s': address is required", ep.Name)
+		if ep.Discovery != nil {
+			if err := ep.Discovery.validate(); err != nil {
+				return fmt.Errorf("endpoint '%s': %w", ep.Name, err)
+			}
+			if len(ep.Addresses) > 0 {
+				return fmt.Errorf("endpoint '%s': addresses cannot be combined with discovery", ep.Name)
+			}
+		} else if len(ep.StaticAddresses()) == 0 {
+			return fmt.Errorf("endpoint '%s': address, addresses or discovery is required", ep.Name)
+		}
+
+		switch ep.GetLoadBalancing() {
+		case LoadBalanceRoundRobin, LoadBalanceLeastOutstanding, LoadBalanceLatencyEWMA:
+		default:
+			return fmt.Errorf("endpoint '%s': invalid load_balancing '%s'", ep.Name, ep.LoadBalancing)
+		}
+
+		if c.Admission != nil && ep.IsEnabled() && ep.ManifestURL == "" {
+			return fmt.Errorf("endpoint '%s': manifest_url is required when admission is configured", ep.Name)
 		}
 
 		// Only RTBExtensionPoint is supported
@@ -237,6 +562,31 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("endpoint '%s': unsupported service type '%s' (only RTBExtensionPoint is supported)", ep.Name, ep.Service)
 		}
 
+		if ep.MaxRetries < 0 {
+			return fmt.Errorf("endpoint '%s': max_retries must not be negative", ep.Name)
+		}
+		if ep.Hedging != nil && (ep.Hedging.Percentile < 0 || ep.Hedging.Percentile > 100) {
+			return fmt.Errorf("endpoint '%s': hedging percentile must be between 0 and 100", ep.Name)
+		}
+
+		if ep.Streaming != nil && ep.Streaming.MaxInFlight < 0 {
+			return fmt.Errorf("endpoint '%s': streaming max_in_flight must not be negative", ep.Name)
+		}
+
+		if ie := ep.IntentEnforcement; ie != nil && (ie.MaxViolations < 0 || ie.WindowSeconds < 0 || ie.DisableSeconds < 0) {
+			return fmt.Errorf("endpoint '%s': intent_enforcement values must not be negative", ep.Name)
+		}
+
+		if ep.Redaction != nil {
+			if err := ep.Redaction.validate(); err != nil {
+				return fmt.Errorf("endpoint '%s': %w", ep.Name, err)
+			}
+		}
+
+		if hc := ep.HealthCheck; hc != nil && hc.GetMethod() != HealthCheckGRPC && hc.GetMethod() != HealthCheckPing {
+			return fmt.Errorf("endpoint '%s': invalid health_check method '%s'", ep.Name, hc.Method)
+		}
+
 		// Validate intents
 		for _, intent := range ep.ApplicableIntents {
 			if !isValidIntent(intent) {
@@ -245,6 +595,13 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate conflict resolution strategies
+	for intent, strategy := range c.ConflictResolution {
+		if err := validateConflictStrategy(intent, strategy); err != nil {
+			return fmt.Errorf("conflict_resolution: %w", err)
+		}
+	}
+
 	return nil
 }
 
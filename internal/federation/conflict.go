@@ -0,0 +1,459 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/protobuf/proto"
+)
+
+// Conflict resolution strategies
+const (
+	// StrategyFirstByPriority keeps the proposal from the highest priority endpoint
+	StrategyFirstByPriority = "first_by_priority"
+
+	// StrategyMin keeps the lowest proposed value
+	StrategyMin = "min"
+
+	// StrategyMax keeps the highest proposed value
+	StrategyMax = "max"
+
+	// StrategyAverage replaces the proposals with their mean value
+	StrategyAverage = "average"
+
+	// StrategyMajority keeps the outcome proposed by the most endpoints
+	StrategyMajority = "majority"
+)
+
+// ConflictStrategies is the list of valid conflict resolution strategies
+var ConflictStrategies = []string{
+	StrategyFirstByPriority,
+	StrategyMin,
+	StrategyMax,
+	StrategyAverage,
+	StrategyMajority,
+}
+
+// ConflictResolution records how contradictory mutations for one target were merged
+type ConflictResolution struct {
+	// Intent is the intent of the winning mutation
+	Intent string `json:"intent"`
+
+	// Target is the path (or deal) the proposals disagreed on
+	Target string `json:"target"`
+
+	// Strategy is the merge strategy that was applied
+	Strategy string `json:"strategy"`
+
+	// Winner is the endpoint whose mutation was kept
+	Winner string `json:"winner"`
+
+	// Value is the resolved value for numeric intents
+	Value *float64 `json:"value,omitempty"`
+
+	// Candidates are the endpoints that proposed a mutation for the target, in priority order
+	Candidates []string `json:"candidates"`
+}
+
+// proposal is a single mutation together with the endpoint that proposed it
+type proposal struct {
+	endpoint string
+	proposed *pb.Mutation // as returned by the endpoint
+	mutation *pb.Mutation // after merging
+	removed  *pb.Mutation // deal IDs removed from a partly kept mutation
+	dropped  bool
+	lostTo   string // winning endpoint, if dropped or partly removed
+	strategy string // strategy that dropped or partly removed it
+}
+
+// numericIntents are the intents that set a single value on a single target
+var numericIntents = map[pb.Intent]bool{
+	pb.Intent_BID_SHADE:          true,
+	pb.Intent_ADJUST_DEAL_FLOOR:  true,
+	pb.Intent_ADJUST_DEAL_MARGIN: true,
+}
+
+// validateConflictStrategy checks that a strategy can be used for an intent
+func validateConflictStrategy(intent, strategy string) error {
+	valid := false
+	for _, s := range ConflictStrategies {
+		if strategy == s {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid strategy '%s' for intent '%s'", strategy, intent)
+	}
+
+	value, ok := pb.Intent_value[strings.ToUpper(intent)]
+	if !ok {
+		return fmt.Errorf("invalid intent '%s'", intent)
+	}
+	switch pb.Intent(value) {
+	case pb.Intent_BID_SHADE, pb.Intent_ADJUST_DEAL_FLOOR, pb.Intent_ADJUST_DEAL_MARGIN:
+		return nil
+	case pb.Intent_ACTIVATE_DEALS, pb.Intent_SUPPRESS_DEALS:
+		if strategy != StrategyFirstByPriority && strategy != StrategyMajority {
+			return fmt.Errorf("intent '%s' only supports %s and %s", intent, StrategyFirstByPriority, StrategyMajority)
+		}
+		return nil
+	default:
+		return fmt.Errorf("intent '%s' has no conflicting targets", intent)
+	}
+}
+
+// GetConflictStrategy returns the merge strategy for an intent, or "" if conflicts are not resolved
+func (c *Config) GetConflictStrategy(intent pb.Intent) string {
+	for name, strategy := range c.ConflictResolution {
+		if strings.EqualFold(name, intent.String()) {
+			return strategy
+		}
+	}
+	return ""
+}
+
+// dealStrategy returns the strategy used when ACTIVATE_DEALS and SUPPRESS_DEALS disagree.
+// SUPPRESS_DEALS takes precedence when both are configured.
+func (c *Config) dealStrategy() string {
+	if s := c.GetConflictStrategy(pb.Intent_SUPPRESS_DEALS); s != "" {
+		return s
+	}
+	return c.GetConflictStrategy(pb.Intent_ACTIVATE_DEALS)
+}
+
+// resolveConflicts merges contradictory mutations across endpoint results.
+// Results must be ordered by endpoint priority; the returned proposals keep
+// that order and mark the mutations that lost a conflict as dropped.
+func resolveConflicts(config *Config, results []FederatedResult) ([]*proposal, []ConflictResolution) {
+	var proposals []*proposal
+	for _, r := range results {
+		if !r.Success {
+			continue
+		}
+		for _, m := range r.Mutations {
+			proposals = append(proposals, &proposal{endpoint: r.EndpointName, proposed: m, mutation: m})
+		}
+	}
+
+	conflicts := resolveNumericConflicts(config, proposals)
+	if strategy := config.dealStrategy(); strategy != "" {
+		conflicts = append(conflicts, resolveDealConflicts(strategy, proposals)...)
+	}
+	return proposals, conflicts
+}
+
+// resolveNumericConflicts merges proposals that set different values on the
+// same target. A floor or margin change to an imp's whole deal list overlaps
+// every change to a single deal on that imp, so they are merged together.
+func resolveNumericConflicts(config *Config, proposals []*proposal) []ConflictResolution {
+	var eligible []*proposal
+	wholeList := make(map[string]bool)
+	for _, p := range proposals {
+		intent := p.mutation.GetIntent()
+		if !numericIntents[intent] || config.GetConflictStrategy(intent) == "" {
+			continue
+		}
+		eligible = append(eligible, p)
+		if path, err := patch.ParsePath(p.mutation.GetPath()); err == nil && path.Kind == patch.PathImpDeals {
+			wholeList[intent.String()+" "+path.ImpID] = true
+		}
+	}
+
+	groups := make(map[string][]*proposal)
+	targets := make(map[string]string)
+	var keys []string
+	for _, p := range eligible {
+		intent := p.mutation.GetIntent().String()
+		target := canonicalPath(p.mutation.GetPath())
+		if path, err := patch.ParsePath(target); err == nil && path.Kind == patch.PathDeal && wholeList[intent+" "+path.ImpID] {
+			target = patch.ImpDealsPath(path.ImpID).String()
+		}
+		key := intent + " " + target
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+			targets[key] = target
+		}
+		groups[key] = append(groups[key], p)
+	}
+
+	var conflicts []ConflictResolution
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+		intent := group[0].mutation.GetIntent()
+		strategy := config.GetConflictStrategy(intent)
+
+		// Margins with mixed calculation types cannot be compared
+		if intent == pb.Intent_ADJUST_DEAL_MARGIN && mixedMarginTypes(group) {
+			strategy = StrategyFirstByPriority
+		}
+
+		winner, value := pickNumeric(strategy, group)
+		for _, p := range group {
+			if p != winner {
+				p.dropped, p.lostTo, p.strategy = true, winner.endpoint, strategy
+			}
+		}
+		if strategy == StrategyAverage {
+			winner.mutation = withNumericValue(winner.mutation, value)
+		}
+
+		conflicts = append(conflicts, ConflictResolution{
+			Intent:     intent.String(),
+			Target:     targets[key],
+			Strategy:   strategy,
+			Winner:     winner.endpoint,
+			Value:      &value,
+			Candidates: candidateNames(group),
+		})
+	}
+	return conflicts
+}
+
+// pickNumeric selects the winning proposal and resolved value for a group
+func pickNumeric(strategy string, group []*proposal) (*proposal, float64) {
+	winner := group[0]
+	value := numericValue(winner.mutation)
+
+	switch strategy {
+	case StrategyMin:
+		for _, p := range group[1:] {
+			if v := numericValue(p.mutation); v < value {
+				winner, value = p, v
+			}
+		}
+	case StrategyMax:
+		for _, p := range group[1:] {
+			if v := numericValue(p.mutation); v > value {
+				winner, value = p, v
+			}
+		}
+	case StrategyAverage:
+		sum := 0.0
+		for _, p := range group {
+			sum += numericValue(p.mutation)
+		}
+		value = sum / float64(len(group))
+	case StrategyMajority:
+		counts := make(map[float64]int)
+		for _, p := range group {
+			counts[numericValue(p.mutation)]++
+		}
+		best := 0
+		for _, p := range group {
+			v := numericValue(p.mutation)
+			if counts[v] > best {
+				winner, value, best = p, v, counts[v]
+			}
+		}
+	}
+	return winner, value
+}
+
+// dealVote is one endpoint's proposal to activate or suppress a deal
+type dealVote struct {
+	proposal *proposal
+	suppress bool
+}
+
+// resolveDealConflicts settles deals that are both activated and suppressed.
+// Losing deal IDs are removed from their mutations and kept in removed;
+// mutations left empty are dropped.
+func resolveDealConflicts(strategy string, proposals []*proposal) []ConflictResolution {
+	votes := make(map[string][]dealVote)
+	var keys []string
+	for _, p := range proposals {
+		intent := p.mutation.GetIntent()
+		if intent != pb.Intent_ACTIVATE_DEALS && intent != pb.Intent_SUPPRESS_DEALS {
+			continue
+		}
+		path, err := patch.ParsePath(p.mutation.GetPath())
+		if err != nil {
+			continue
+		}
+		for _, id := range p.mutation.GetIds().GetId() {
+			key := patch.DealPath(path.ImpID, id).String()
+			if _, exists := votes[key]; !exists {
+				keys = append(keys, key)
+			}
+			votes[key] = append(votes[key], dealVote{proposal: p, suppress: intent == pb.Intent_SUPPRESS_DEALS})
+		}
+	}
+
+	removals := make(map[*proposal]map[string]bool)
+	var conflicts []ConflictResolution
+	for _, key := range keys {
+		group := votes[key]
+		activate, suppress := 0, 0
+		for _, v := range group {
+			if v.suppress {
+				suppress++
+			} else {
+				activate++
+			}
+		}
+		if activate == 0 || suppress == 0 {
+			continue
+		}
+
+		// First by priority, or the majority action with ties broken by priority
+		winner := group[0]
+		if strategy == StrategyMajority && activate != suppress {
+			wantSuppress := suppress > activate
+			for _, v := range group {
+				if v.suppress == wantSuppress {
+					winner = v
+					break
+				}
+			}
+		}
+
+		dealID := key[strings.LastIndex(key, "/")+1:]
+		var names []string
+		for _, v := range group {
+			names = append(names, v.proposal.endpoint)
+			if v.suppress != winner.suppress {
+				if removals[v.proposal] == nil {
+					removals[v.proposal] = make(map[string]bool)
+				}
+				removals[v.proposal][dealID] = true
+				v.proposal.lostTo, v.proposal.strategy = winner.proposal.endpoint, strategy
+			}
+		}
+
+		conflicts = append(conflicts, ConflictResolution{
+			Intent:     winner.proposal.mutation.GetIntent().String(),
+			Target:     key,
+			Strategy:   strategy,
+			Winner:     winner.proposal.endpoint,
+			Candidates: names,
+		})
+	}
+
+	for p, ids := range removals {
+		var kept, removed []string
+		for _, id := range p.mutation.GetIds().GetId() {
+			if ids[id] {
+				removed = append(removed, id)
+			} else {
+				kept = append(kept, id)
+			}
+		}
+		if len(kept) == 0 {
+			p.dropped = true
+			continue
+		}
+		p.mutation = withIDs(p.mutation, kept)
+		p.removed = withIDs(p.mutation, removed)
+	}
+	return conflicts
+}
+
+// withIDs returns a copy of an IDs mutation with its IDs replaced
+func withIDs(m *pb.Mutation, ids []string) *pb.Mutation {
+	clone := proto.Clone(m).(*pb.Mutation)
+	clone.Value = &pb.Mutation_Ids{Ids: &pb.IDsPayload{Id: ids}}
+	return clone
+}
+
+// rankResults returns a copy of results ordered by endpoint priority, then by
+// position in the config
+func rankResults(config *Config, results []FederatedResult) []FederatedResult {
+	index := make(map[string]int, len(config.Endpoints))
+	for i, ep := range config.Endpoints {
+		index[ep.Name] = i
+	}
+	priority := func(name string) int {
+		if ep := config.GetEndpointByName(name); ep != nil {
+			return ep.Priority
+		}
+		return 0
+	}
+	ranked := append([]FederatedResult(nil), results...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		pi, pj := priority(ranked[i].EndpointName), priority(ranked[j].EndpointName)
+		if pi != pj {
+			return pi < pj
+		}
+		return index[ranked[i].EndpointName] < index[ranked[j].EndpointName]
+	})
+	return ranked
+}
+
+// numericValue returns the value a numeric mutation sets
+func numericValue(m *pb.Mutation) float64 {
+	switch m.GetIntent() {
+	case pb.Intent_BID_SHADE:
+		return m.GetAdjustBid().GetPrice()
+	case pb.Intent_ADJUST_DEAL_FLOOR:
+		return m.GetAdjustDeal().GetBidfloor()
+	case pb.Intent_ADJUST_DEAL_MARGIN:
+		return m.GetAdjustDeal().GetMargin().GetValue()
+	}
+	return 0
+}
+
+// withNumericValue returns a copy of a numeric mutation with its value replaced
+func withNumericValue(m *pb.Mutation, value float64) *pb.Mutation {
+	clone := proto.Clone(m).(*pb.Mutation)
+	switch clone.GetIntent() {
+	case pb.Intent_BID_SHADE:
+		clone.GetAdjustBid().Price = proto.Float64(value)
+	case pb.Intent_ADJUST_DEAL_FLOOR:
+		clone.GetAdjustDeal().Bidfloor = proto.Float64(value)
+	case pb.Intent_ADJUST_DEAL_MARGIN:
+		clone.GetAdjustDeal().GetMargin().Value = proto.Float64(value)
+	}
+	return clone
+}
+
+// mixedMarginTypes reports whether margin proposals use different calculation types
+func mixedMarginTypes(group []*proposal) bool {
+	first := group[0].mutation.GetAdjustDeal().GetMargin().GetCalculationType()
+	for _, p := range group[1:] {
+		if p.mutation.GetAdjustDeal().GetMargin().GetCalculationType() != first {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalPath normalizes a path so equivalent spellings compare equal
+func canonicalPath(path string) string {
+	if p, err := patch.ParsePath(path); err == nil {
+		return p.String()
+	}
+	return path
+}
+
+// candidateNames returns the endpoint names of a group of proposals
+func candidateNames(group []*proposal) []string {
+	names := make([]string, 0, len(group))
+	for _, p := range group {
+		names = append(names, p.endpoint)
+	}
+	return names
+}
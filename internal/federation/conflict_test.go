@@ -0,0 +1,390 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+)
+
+// conflictConfig returns a config with endpoints a, b and c in priority order
+func conflictConfig(strategies map[string]string) *Config {
+	return &Config{
+		Endpoints: []EndpointConfig{
+			{Name: "a", Priority: 1},
+			{Name: "b", Priority: 2},
+			{Name: "c", Priority: 3},
+		},
+		ConflictResolution: strategies,
+	}
+}
+
+// proposed returns one successful result per endpoint, in the given order
+func proposed(names []string, mutations ...*pb.Mutation) []FederatedResult {
+	results := make([]FederatedResult, len(names))
+	for i, name := range names {
+		results[i] = FederatedResult{EndpointName: name, Success: true, Mutations: []*pb.Mutation{mutations[i]}}
+	}
+	return results
+}
+
+func TestResolveNumericStrategies(t *testing.T) {
+	tests := []struct {
+		strategy string
+		prices   []float64
+		winner   string
+		value    float64
+	}{
+		{StrategyFirstByPriority, []float64{5, 3, 8}, "a", 5},
+		{StrategyMin, []float64{5, 3, 8}, "b", 3},
+		{StrategyMax, []float64{5, 3, 8}, "c", 8},
+		{StrategyAverage, []float64{5, 3, 7}, "a", 5},
+		{StrategyMajority, []float64{5, 3, 3}, "b", 3},
+		{StrategyMajority, []float64{5, 3, 8}, "a", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			config := conflictConfig(map[string]string{"BID_SHADE": tt.strategy})
+			results := proposed([]string{"a", "b", "c"},
+				shadeMutation(tt.prices[0]), shadeMutation(tt.prices[1]), shadeMutation(tt.prices[2]))
+
+			proposals, conflicts := resolveConflicts(config, results)
+			if len(conflicts) != 1 {
+				t.Fatalf("got %d conflicts, want 1", len(conflicts))
+			}
+			c := conflicts[0]
+			if c.Winner != tt.winner || c.Strategy != tt.strategy || *c.Value != tt.value {
+				t.Errorf("got winner %s by %s at %v, want %s by %s at %v", c.Winner, c.Strategy, *c.Value, tt.winner, tt.strategy, tt.value)
+			}
+			if want := []string{"a", "b", "c"}; !reflect.DeepEqual(c.Candidates, want) {
+				t.Errorf("got candidates %v, want %v", c.Candidates, want)
+			}
+			for _, p := range proposals {
+				if p.dropped != (p.endpoint != tt.winner) {
+					t.Errorf("%s: got dropped %v", p.endpoint, p.dropped)
+				}
+				if p.dropped && (p.lostTo != tt.winner || p.strategy != tt.strategy) {
+					t.Errorf("%s: got lost to %s by %s", p.endpoint, p.lostTo, p.strategy)
+				}
+				if !p.dropped && numericValue(p.mutation) != tt.value {
+					t.Errorf("%s: got value %v, want %v", p.endpoint, numericValue(p.mutation), tt.value)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveNumericAverageKeepsProposal(t *testing.T) {
+	config := conflictConfig(map[string]string{"BID_SHADE": StrategyAverage})
+	first := shadeMutation(4)
+	proposals, _ := resolveConflicts(config, proposed([]string{"a", "b"}, first, shadeMutation(6)))
+
+	if proposals[0].mutation == first || proposals[0].proposed != first {
+		t.Fatal("averaging should replace the mutation and keep the proposal")
+	}
+	if got := first.GetAdjustBid().GetPrice(); got != 4 {
+		t.Errorf("proposed mutation changed to %v", got)
+	}
+}
+
+func TestResolveNumericMixedMargins(t *testing.T) {
+	config := conflictConfig(map[string]string{"ADJUST_DEAL_MARGIN": StrategyMax})
+	results := proposed([]string{"a", "b"},
+		marginMutation("/imp/1/pmp/deals/deal-a", 5, pb.Margin_CPM),
+		marginMutation("/imp/1/pmp/deals/deal-a", 20, pb.Margin_PERCENT))
+
+	_, conflicts := resolveConflicts(config, results)
+	if len(conflicts) != 1 {
+		t.Fatalf("got %d conflicts, want 1", len(conflicts))
+	}
+	if c := conflicts[0]; c.Strategy != StrategyFirstByPriority || c.Winner != "a" {
+		t.Errorf("got winner %s by %s, want a by %s", c.Winner, c.Strategy, StrategyFirstByPriority)
+	}
+}
+
+func TestResolveNumericTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		paths     []string
+		conflicts []string
+	}{
+		{
+			name:      "same deal",
+			paths:     []string{"/imp/1/pmp/deals/deal-a", "/imp/1/pmp/deals/deal-a"},
+			conflicts: []string{"/imp/1/pmp/deals/deal-a"},
+		},
+		{
+			name:  "different deals",
+			paths: []string{"/imp/1/pmp/deals/deal-a", "/imp/1/pmp/deals/deal-free"},
+		},
+		{
+			name:  "different imps",
+			paths: []string{"/imp/1/pmp/deals", "/imp/2/pmp/deals/deal-a"},
+		},
+		{
+			name:      "whole list and one deal",
+			paths:     []string{"/imp/1/pmp/deals", "/imp/1/pmp/deals/deal-a"},
+			conflicts: []string{"/imp/1/pmp/deals"},
+		},
+		{
+			name:      "one deal and whole list",
+			paths:     []string{"/imp/1/pmp/deals/deal-a", "/imp/1/pmp/deals"},
+			conflicts: []string{"/imp/1/pmp/deals"},
+		},
+		{
+			name:      "whole list and several deals",
+			paths:     []string{"/imp/1/pmp/deals/deal-a", "/imp/1/pmp/deals/deal-free", "/imp/1/pmp/deals"},
+			conflicts: []string{"/imp/1/pmp/deals"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := conflictConfig(map[string]string{"ADJUST_DEAL_FLOOR": StrategyMax})
+			names := []string{"a", "b", "c"}[:len(tt.paths)]
+			var mutations []*pb.Mutation
+			for i, path := range tt.paths {
+				mutations = append(mutations, floorMutation(path, float64(i+1)))
+			}
+
+			proposals, conflicts := resolveConflicts(config, proposed(names, mutations...))
+			var targets []string
+			for _, c := range conflicts {
+				targets = append(targets, c.Target)
+			}
+			if !reflect.DeepEqual(targets, tt.conflicts) {
+				t.Fatalf("got conflicts on %v, want %v", targets, tt.conflicts)
+			}
+			want := len(tt.paths)
+			if len(tt.conflicts) > 0 {
+				want = 1
+			}
+			kept := 0
+			for _, p := range proposals {
+				if !p.dropped {
+					kept++
+				}
+			}
+			if kept != want {
+				t.Errorf("kept %d proposals, want %d", kept, want)
+			}
+		})
+	}
+}
+
+func TestResolveNumericUnconfigured(t *testing.T) {
+	config := conflictConfig(map[string]string{"ADJUST_DEAL_FLOOR": StrategyMax})
+	proposals, conflicts := resolveConflicts(config, proposed([]string{"a", "b"}, shadeMutation(4), shadeMutation(6)))
+
+	if len(conflicts) != 0 {
+		t.Errorf("got %d conflicts, want none", len(conflicts))
+	}
+	for _, p := range proposals {
+		if p.dropped {
+			t.Errorf("%s: dropped without a strategy", p.endpoint)
+		}
+	}
+}
+
+func TestResolveDealConflicts(t *testing.T) {
+	suppress := func(ids ...string) *pb.Mutation {
+		return idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", ids...)
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		names    []string
+		muts     []*pb.Mutation
+		winners  []string          // winner per conflicting deal
+		kept     map[string]string // endpoint -> kept IDs, "" if dropped
+		removed  map[string]string // endpoint -> removed IDs of a partly kept mutation
+	}{
+		{
+			name:     "first by priority drops the whole mutation",
+			strategy: StrategyFirstByPriority,
+			names:    []string{"a", "b"},
+			muts:     []*pb.Mutation{suppress("deal-a"), dealsMutation("deal-a")},
+			winners:  []string{"a"},
+			kept:     map[string]string{"a": "deal-a", "b": ""},
+		},
+		{
+			name:     "first by priority removes the losing deal",
+			strategy: StrategyFirstByPriority,
+			names:    []string{"a", "b"},
+			muts:     []*pb.Mutation{dealsMutation("deal-a"), suppress("deal-a", "deal-free")},
+			winners:  []string{"a"},
+			kept:     map[string]string{"a": "deal-a", "b": "deal-free"},
+			removed:  map[string]string{"b": "deal-a"},
+		},
+		{
+			name:     "majority outvotes priority",
+			strategy: StrategyMajority,
+			names:    []string{"a", "b", "c"},
+			muts:     []*pb.Mutation{dealsMutation("deal-a", "deal-free"), suppress("deal-a"), suppress("deal-a")},
+			winners:  []string{"b"},
+			kept:     map[string]string{"a": "deal-free", "b": "deal-a", "c": "deal-a"},
+			removed:  map[string]string{"a": "deal-a"},
+		},
+		{
+			name:     "majority tie goes to priority",
+			strategy: StrategyMajority,
+			names:    []string{"a", "b"},
+			muts:     []*pb.Mutation{suppress("deal-a"), dealsMutation("deal-a")},
+			winners:  []string{"a"},
+			kept:     map[string]string{"a": "deal-a", "b": ""},
+		},
+		{
+			name:     "no overlap",
+			strategy: StrategyMajority,
+			names:    []string{"a", "b"},
+			muts:     []*pb.Mutation{suppress("deal-a"), dealsMutation("deal-free")},
+			kept:     map[string]string{"a": "deal-a", "b": "deal-free"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := conflictConfig(map[string]string{"SUPPRESS_DEALS": tt.strategy})
+			proposals, conflicts := resolveConflicts(config, proposed(tt.names, tt.muts...))
+
+			var winners []string
+			for _, c := range conflicts {
+				winners = append(winners, c.Winner)
+			}
+			if !reflect.DeepEqual(winners, tt.winners) {
+				t.Errorf("got winners %v, want %v", winners, tt.winners)
+			}
+			for _, p := range proposals {
+				want := tt.kept[p.endpoint]
+				if want == "" {
+					if !p.dropped {
+						t.Errorf("%s: kept %v, want dropped", p.endpoint, p.mutation.GetIds().GetId())
+					}
+					continue
+				}
+				if got := joinIDs(p.mutation); p.dropped || got != want {
+					t.Errorf("%s: kept %q (dropped %v), want %q", p.endpoint, got, p.dropped, want)
+				}
+				if got := joinIDs(p.removed); got != tt.removed[p.endpoint] {
+					t.Errorf("%s: removed %q, want %q", p.endpoint, got, tt.removed[p.endpoint])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveAuditsRemovedDeals(t *testing.T) {
+	ring := audit.NewRingBuffer(10)
+	m := &Manager{audit: audit.NewLogger(ring)}
+	config := conflictConfig(map[string]string{"SUPPRESS_DEALS": StrategyFirstByPriority})
+	req := policyRequest()
+	suppress := idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", "deal-a")
+	activate := dealsMutation("deal-a", "deal-free")
+	results := proposed([]string{"a", "b"}, suppress, activate)
+
+	trail := m.newAuditTrail(req)
+	trail.add(results)
+	resolved, _ := m.resolve(config, results, trail)
+	m.auditAccepted(trail, resolved)
+	m.audit.Close()
+
+	records := ring.Query(audit.Query{Endpoint: "b"})
+	if len(records) != 2 {
+		t.Fatalf("got %d records for b, want 2: %+v", len(records), records)
+	}
+	hashes := map[string]bool{}
+	for _, r := range records {
+		hashes[r.PayloadHash] = true
+		want := audit.PayloadHash(dealsMutation("deal-free"))
+		if !r.Accepted {
+			want = audit.PayloadHash(dealsMutation("deal-a"))
+			if r.Stage != audit.StageConflict || r.Code != "conflict" {
+				t.Errorf("got rejection at %s with %s, want %s with conflict", r.Stage, r.Code, audit.StageConflict)
+			}
+		}
+		if r.PayloadHash != want {
+			t.Errorf("accepted %v: got payload hash %s, want %s", r.Accepted, r.PayloadHash, want)
+		}
+	}
+	if len(hashes) != 2 {
+		t.Error("kept and removed deal IDs should be audited separately")
+	}
+	if got := ring.Query(audit.Query{Endpoint: "a"}); len(got) != 1 || !got[0].Accepted {
+		t.Errorf("got %+v for a, want one accepted record", got)
+	}
+}
+
+func TestRankResults(t *testing.T) {
+	config := conflictConfig(nil)
+	config.Endpoints = append(config.Endpoints, EndpointConfig{Name: "d", Priority: 1})
+	results := proposed([]string{"c", "d", "b", "a"},
+		shadeMutation(1), shadeMutation(2), shadeMutation(3), shadeMutation(4))
+
+	var names []string
+	for _, r := range rankResults(config, results) {
+		names = append(names, r.EndpointName)
+	}
+	if want := []string{"a", "d", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got order %v, want %v", names, want)
+	}
+	if results[0].EndpointName != "c" {
+		t.Error("rankResults reordered its input")
+	}
+}
+
+func TestValidateConflictStrategy(t *testing.T) {
+	tests := []struct {
+		intent   string
+		strategy string
+		wantErr  bool
+	}{
+		{"BID_SHADE", StrategyAverage, false},
+		{"adjust_deal_floor", StrategyMin, false},
+		{"ADJUST_DEAL_MARGIN", StrategyMajority, false},
+		{"ACTIVATE_DEALS", StrategyMajority, false},
+		{"SUPPRESS_DEALS", StrategyFirstByPriority, false},
+		{"SUPPRESS_DEALS", StrategyMax, true},
+		{"ACTIVATE_SEGMENTS", StrategyFirstByPriority, true},
+		{"NOT_AN_INTENT", StrategyMin, true},
+		{"BID_SHADE", "median", true},
+	}
+
+	for _, tt := range tests {
+		err := validateConflictStrategy(tt.intent, tt.strategy)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s with %s: got error %v, want error %v", tt.intent, tt.strategy, err, tt.wantErr)
+		}
+	}
+}
+
+// joinIDs returns the IDs of a mutation separated by commas
+func joinIDs(m *pb.Mutation) string {
+	ids := ""
+	for i, id := range m.GetIds().GetId() {
+		if i > 0 {
+			ids += ","
+		}
+		ids += id
+	}
+	return ids
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Discovery source types
+const (
+	// DiscoveryDNS resolves A/AAAA records and combines them with a port
+	DiscoveryDNS = "dns"
+
+	// DiscoverySRV resolves SRV records, which carry both host and port
+	DiscoverySRV = "srv"
+
+	// DiscoveryFile reads instance addresses from a watched JSON file
+	DiscoveryFile = "file"
+)
+
+// Resolver looks up DNS records for endpoint discovery.
+// *net.Resolver satisfies this interface.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// resolver is the DNS resolver used by discovery
+var resolver Resolver = net.DefaultResolver
+
+// SetResolver replaces the DNS resolver used for discovery.
+// It must be called before any client with DNS discovery is created.
+func SetResolver(r Resolver) {
+	resolver = r
+}
+
+// DiscoveryFileContent is the JSON document read by file-based discovery
+type DiscoveryFileContent struct {
+	// Addresses are the host:port addresses of the endpoint instances
+	Addresses []string `json:"addresses"`
+}
+
+// startDiscovery resolves the endpoint's instances and keeps them up to date in the background
+func (c *Client) startDiscovery() {
+	d := c.config.Discovery
+	if d == nil {
+		return
+	}
+
+	// Stamp the discovery file before the first read so no change is missed
+	var stamp fileStamp
+	if d.Type == DiscoveryFile {
+		stamp = statFile(d.Path)
+	}
+
+	// Resolve once up front so the client is usable immediately
+	c.refreshInstances()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopDiscovery = cancel
+	c.discoveryDone = make(chan struct{})
+	log.Printf("[Federation] Discovering instances of endpoint '%s' from %s every %v",
+		c.config.Name, d, d.GetRefresh())
+
+	go func() {
+		defer close(c.discoveryDone)
+		if d.Type == DiscoveryFile {
+			watchFileFrom(ctx, d.Path, d.GetRefresh(), stamp, c.refreshInstances)
+			return
+		}
+		ticker := time.NewTicker(d.GetRefresh())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.refreshInstances()
+			}
+		}
+	}()
+}
+
+// stopDiscoveryLoop stops background discovery and waits for it to exit
+func (c *Client) stopDiscoveryLoop() {
+	if c.stopDiscovery == nil {
+		return
+	}
+	c.stopDiscovery()
+	<-c.discoveryDone
+	c.stopDiscovery = nil
+}
+
+// refreshInstances resolves the discovery source and updates the replica set.
+// On a lookup error the current instances are kept.
+func (c *Client) refreshInstances() {
+	d := c.config.Discovery
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addresses, err := d.resolve(ctx)
+	if err != nil {
+		log.Printf("[Federation] Discovery for endpoint '%s' failed, keeping %d instances: %v",
+			c.config.Name, len(c.Instances()), err)
+		return
+	}
+	c.setAddresses(addresses)
+}
+
+// resolve returns the sorted instance addresses from the discovery source
+func (d *DiscoveryConfig) resolve(ctx context.Context) ([]string, error) {
+	var addresses []string
+	switch d.Type {
+	case DiscoveryDNS:
+		hosts, err := resolver.LookupHost(ctx, d.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range hosts {
+			addresses = append(addresses, net.JoinHostPort(host, strconv.Itoa(d.Port)))
+		}
+	case DiscoverySRV:
+		_, records, err := resolver.LookupSRV(ctx, "", "", d.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range records {
+			addresses = append(addresses, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+		}
+	case DiscoveryFile:
+		data, err := os.ReadFile(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		var content DiscoveryFileContent
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to parse discovery file: %w", err)
+		}
+		addresses = content.Addresses
+	default:
+		return nil, fmt.Errorf("unsupported discovery type '%s'", d.Type)
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
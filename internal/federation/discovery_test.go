@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// stubResolver answers discovery lookups from fixed records
+type stubResolver struct {
+	mu    sync.Mutex
+	hosts map[string][]string
+	srv   map[string][]*net.SRV
+	err   error
+}
+
+func (r *stubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.hosts[host], nil
+}
+
+func (r *stubResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return "", nil, r.err
+	}
+	return name, r.srv[name], nil
+}
+
+func (r *stubResolver) setHosts(host string, addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hosts[host] = addrs
+	r.err = nil
+}
+
+func (r *stubResolver) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+// useResolver installs r for the duration of the test
+func useResolver(t *testing.T, r Resolver) {
+	previous := resolver
+	SetResolver(r)
+	t.Cleanup(func() { SetResolver(previous) })
+}
+
+func TestResolveDNS(t *testing.T) {
+	useResolver(t, &stubResolver{hosts: map[string][]string{
+		"agent.example.internal": {"10.0.0.2", "2001:db8::1", "10.0.0.1"},
+	}})
+
+	d := &DiscoveryConfig{Type: DiscoveryDNS, Name: "agent.example.internal", Port: 50051}
+	got, err := d.resolve(context.Background())
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	want := []string{"10.0.0.1:50051", "10.0.0.2:50051", "[2001:db8::1]:50051"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("addresses = %v, want %v", got, want)
+	}
+}
+
+func TestResolveSRV(t *testing.T) {
+	name := "_grpc._tcp.agent.example.internal"
+	useResolver(t, &stubResolver{srv: map[string][]*net.SRV{
+		name: {
+			{Target: "agent-b.example.internal.", Port: 50052},
+			{Target: "agent-a.example.internal.", Port: 50051},
+		},
+	}})
+
+	d := &DiscoveryConfig{Type: DiscoverySRV, Name: name}
+	got, err := d.resolve(context.Background())
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	want := []string{"agent-a.example.internal:50051", "agent-b.example.internal:50052"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("addresses = %v, want %v", got, want)
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "addresses",
+			path: write("agents.json", `{"addresses": ["agent-b:50051", "agent-a:50051"]}`),
+			want: []string{"agent-a:50051", "agent-b:50051"},
+		},
+		{
+			name: "empty",
+			path: write("empty.json", `{"addresses": []}`),
+			want: []string{},
+		},
+		{
+			name:    "invalid json",
+			path:    write("invalid.json", `{"addresses": [`),
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			path:    filepath.Join(dir, "missing.json"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DiscoveryConfig{Type: DiscoveryFile, Path: tt.path}
+			got, err := d.resolve(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("resolve = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("addresses = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshInstances(t *testing.T) {
+	stub := &stubResolver{hosts: map[string][]string{}}
+	stub.setHosts("agent.example.internal", "10.0.0.1", "10.0.0.2")
+	useResolver(t, stub)
+
+	client, err := NewClient(EndpointConfig{
+		Name: "discovered-agent",
+		Discovery: &DiscoveryConfig{
+			Type:           DiscoveryDNS,
+			Name:           "agent.example.internal",
+			Port:           50051,
+			RefreshSeconds: 3600,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	check := func(want ...string) {
+		t.Helper()
+		if got := client.Instances(); !reflect.DeepEqual(got, want) {
+			t.Errorf("instances = %v, want %v", got, want)
+		}
+	}
+	check("10.0.0.1:50051", "10.0.0.2:50051")
+	kept := client.replicaSet()[1]
+
+	// 10.0.0.1 is removed and 10.0.0.3 added; 10.0.0.2 keeps its connection
+	stub.setHosts("agent.example.internal", "10.0.0.3", "10.0.0.2")
+	client.refreshInstances()
+	check("10.0.0.2:50051", "10.0.0.3:50051")
+	if client.replicaSet()[0] != kept {
+		t.Error("replica for 10.0.0.2 was reconnected")
+	}
+
+	// A failed lookup keeps the current instances
+	stub.fail(errors.New("no such host"))
+	client.refreshInstances()
+	check("10.0.0.2:50051", "10.0.0.3:50051")
+}
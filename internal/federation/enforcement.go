@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/validate"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+)
+
+// IntentEnforcementConfig disables an endpoint that keeps returning mutations
+// for intents outside its applicable_intents. Such mutations are always
+// dropped; this only controls whether the endpoint is taken out of rotation.
+type IntentEnforcementConfig struct {
+	// MaxViolations is the number of responses with out-of-scope mutations
+	// within the window that disables the endpoint (0 never disables it)
+	MaxViolations int `json:"max_violations" yaml:"max_violations"`
+
+	// WindowSeconds is the period over which violations are counted (default: 60)
+	WindowSeconds int `json:"window_seconds,omitempty" yaml:"window_seconds,omitempty"`
+
+	// DisableSeconds is how long the endpoint stays disabled; 0 disables it
+	// until the federation config is reloaded
+	DisableSeconds int `json:"disable_seconds,omitempty" yaml:"disable_seconds,omitempty"`
+}
+
+// GetWindow returns the period over which violations are counted
+func (e *IntentEnforcementConfig) GetWindow() time.Duration {
+	if e.WindowSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(e.WindowSeconds) * time.Second
+}
+
+// GetIntentEnforcement returns the endpoint's intent enforcement policy, or nil if it never auto-disables
+func (e *EndpointConfig) GetIntentEnforcement(defaults *EndpointDefaults) *IntentEnforcementConfig {
+	enforcement := e.IntentEnforcement
+	if enforcement == nil && defaults != nil {
+		enforcement = defaults.IntentEnforcement
+	}
+	if enforcement == nil || enforcement.MaxViolations <= 0 {
+		return nil
+	}
+	return enforcement
+}
+
+// intentGuard tracks an endpoint's out-of-scope responses and disables it
+// when they exceed the policy
+type intentGuard struct {
+	name          string
+	config        IntentEnforcementConfig
+	mu            sync.Mutex
+	offenses      []time.Time
+	disabled      bool
+	disabledUntil time.Time
+	reason        string
+}
+
+// newIntentGuard creates a guard for the endpoint, or nil if no policy applies
+func newIntentGuard(name string, config *IntentEnforcementConfig) *intentGuard {
+	if config == nil {
+		return nil
+	}
+	return &intentGuard{name: name, config: *config}
+}
+
+// record counts a response that contained out-of-scope mutations
+func (g *intentGuard) record(violations []validate.Violation) {
+	if g == nil || len(violations) == 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-g.config.GetWindow())
+	kept := g.offenses[:0]
+	for _, t := range g.offenses {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	g.offenses = append(kept, now)
+	if g.disabled || len(g.offenses) < g.config.MaxViolations {
+		return
+	}
+
+	intents := make([]string, 0, len(violations))
+	for _, v := range violations {
+		intents = append(intents, v.Intent.String())
+	}
+	g.disabled = true
+	g.offenses = nil
+	g.reason = fmt.Sprintf("%d responses with out-of-scope intents within %v (last: %s)",
+		g.config.MaxViolations, g.config.GetWindow(), strings.Join(intents, ", "))
+	g.disabledUntil = time.Time{}
+	if g.config.DisableSeconds > 0 {
+		g.disabledUntil = now.Add(time.Duration(g.config.DisableSeconds) * time.Second)
+	}
+	log.Printf("[Federation] Endpoint '%s' disabled: %s", g.name, g.reason)
+}
+
+// reset re-enables the endpoint and forgets its offenses, as when the
+// federation config is reloaded
+func (g *intentGuard) reset() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.disabled {
+		log.Printf("[Federation] Endpoint '%s' re-enabled by config reload", g.name)
+	}
+	g.disabled = false
+	g.offenses = nil
+	g.reason = ""
+	g.disabledUntil = time.Time{}
+}
+
+// disabledReason returns why the endpoint is disabled, or "" if it is enabled
+func (g *intentGuard) disabledReason() string {
+	if g == nil {
+		return ""
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.disabled && !g.disabledUntil.IsZero() && time.Now().After(g.disabledUntil) {
+		g.disabled = false
+		log.Printf("[Federation] Endpoint '%s' re-enabled after intent enforcement period", g.name)
+	}
+	if !g.disabled {
+		return ""
+	}
+	return g.reason
+}
+
+// enforceIntents drops mutations for intents outside the endpoint's
+// applicable_intents and counts the response against the endpoint
+func (c *Client) enforceIntents(mutations []*pb.Mutation) ([]*pb.Mutation, []validate.Violation) {
+	if len(c.config.ApplicableIntents) == 0 {
+		return mutations, nil
+	}
+	inScope, violations := validate.FilterIntents(mutations, func(intent pb.Intent) bool {
+		return c.config.HasIntent(intent.String())
+	})
+	if len(violations) > 0 {
+		c.dropped.Add(violations)
+		for _, v := range violations {
+			log.Printf("[Federation] Endpoint '%s' sent out-of-scope %s", c.config.Name, v)
+		}
+		c.guard.record(violations)
+	}
+	return inScope, violations
+}
+
+// DisabledReason returns why intent enforcement disabled the endpoint, or "" if it is enabled
+func (c *Client) DisabledReason() string {
+	return c.guard.disabledReason()
+}
+
+// IntentViolations returns the number of out-of-scope mutations dropped from this endpoint
+func (c *Client) IntentViolations() uint64 {
+	return c.dropped.Count(validate.ReasonIntentNotApplicable)
+}
@@ -22,47 +22,75 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/metrics"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/privacy"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/validate"
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
 	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 )
 
 // Manager coordinates federated GRPC calls across multiple endpoints
 type Manager struct {
-	pool   *ClientPool
-	config *Config
+	pool     *ClientPool
+	config   *Config
+	policy   *PolicyEngine
+	privacy  *privacy.Gate
+	audit    *audit.Logger
+	metrics  *metrics.Metrics
+	mu       sync.RWMutex
+	reloadMu sync.Mutex
 }
 
 // FederatedResult contains the result from a single federated endpoint
 type FederatedResult struct {
-	EndpointName string         `json:"endpoint_name"`
-	Success      bool           `json:"success"`
-	Mutations    []*pb.Mutation `json:"mutations,omitempty"`
-	Error        string         `json:"error,omitempty"`
-	LatencyMs    int64          `json:"latency_ms"`
+	EndpointName     string            `json:"endpoint_name"`
+	Success          bool              `json:"success"`
+	Mutations        []*pb.Mutation    `json:"mutations,omitempty"`
+	DroppedMutations int               `json:"dropped_mutations,omitempty"`
+	PolicyDecisions  []PolicyDecision  `json:"policy_decisions,omitempty"`
+	Privacy          *privacy.Decision `json:"privacy,omitempty"`
+	Error            string            `json:"error,omitempty"`
+	LatencyMs        int64             `json:"latency_ms"`
 }
 
 // FederatedResponse contains aggregated results from all endpoints
 type FederatedResponse struct {
-	ID              string            `json:"id"`
-	Mutations       []*pb.Mutation    `json:"mutations"`
-	EndpointResults []FederatedResult `json:"endpoint_results"`
-	TotalLatencyMs  int64             `json:"total_latency_ms"`
-	Metadata        *pb.Metadata      `json:"metadata,omitempty"`
+	ID              string               `json:"id"`
+	Mutations       []*pb.Mutation       `json:"mutations"`
+	EndpointResults []FederatedResult    `json:"endpoint_results"`
+	Conflicts       []ConflictResolution `json:"conflicts,omitempty"`
+	TotalLatencyMs  int64                `json:"total_latency_ms"`
+	Metadata        *pb.Metadata         `json:"metadata,omitempty"`
 }
 
 // EndpointInfo provides information about a federated endpoint
 type EndpointInfo struct {
-	Name              string   `json:"name"`
-	Address           string   `json:"address"`
-	Description       string   `json:"description,omitempty"`
-	Service           string   `json:"service"`
-	ApplicableIntents []string `json:"applicable_intents"`
-	Priority          int      `json:"priority"`
-	Enabled           bool     `json:"enabled"`
-	Healthy           bool     `json:"healthy"`
-	TimeoutMs         int      `json:"timeout_ms"`
+	Name              string           `json:"name"`
+	Address           string           `json:"address"`
+	Description       string           `json:"description,omitempty"`
+	Service           string           `json:"service"`
+	ApplicableIntents []string         `json:"applicable_intents"`
+	Priority          int              `json:"priority"`
+	Enabled           bool             `json:"enabled"`
+	Healthy           bool             `json:"healthy"`
+	CircuitState      string           `json:"circuit_state,omitempty"`
+	Replicas          []ReplicaInfo    `json:"replicas,omitempty"`
+	AllowedFields     []string         `json:"allowed_fields,omitempty"`
+	Admission         *AdmissionResult `json:"admission,omitempty"`
+	TimeoutMs         int              `json:"timeout_ms"`
+	DroppedMutations  uint64           `json:"dropped_mutations"`
+	IntentViolations  uint64           `json:"intent_violations"`
+	DisabledReason    string           `json:"disabled_reason,omitempty"`
+	ProbeHistory      []ProbeResult    `json:"probe_history,omitempty"`
 }
 
 // NewManager creates a new federation manager
@@ -90,8 +118,6 @@ func NewManagerFromFile(configPath string) (*Manager, error) {
 
 // GetMutations calls all applicable federated endpoints and aggregates results
 func (m *Manager) GetMutations(ctx context.Context, req *pb.RTBRequest, acceptableIntents []string) (*FederatedResponse, error) {
-	startTime := time.Now()
-
 	// Get endpoints that match the acceptable intents
 	var clients []*Client
 	if len(acceptableIntents) == 0 {
@@ -108,14 +134,85 @@ func (m *Manager) GetMutations(ctx context.Context, req *pb.RTBRequest, acceptab
 			clients = append(clients, c)
 		}
 	}
+	return m.federate(ctx, req, clients, acceptableIntents, nil), nil
+}
+
+// CallEndpoints calls the named endpoints and aggregates their results exactly
+// as GetMutations does for the endpoints it selects. Names that cannot be
+// called are reported as failed results.
+func (m *Manager) CallEndpoints(ctx context.Context, req *pb.RTBRequest, endpointNames []string, acceptableIntents []string) (*FederatedResponse, error) {
+	var clients []*Client
+	var failed []FederatedResult
+	seen := make(map[string]bool)
+	for _, name := range endpointNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		client, err := m.endpointClient(name, acceptableIntents)
+		if err != nil {
+			log.Printf("[Federation] Cannot call endpoint '%s': %v", name, err)
+			failed = append(failed, FederatedResult{EndpointName: name, Error: err.Error()})
+			continue
+		}
+		clients = append(clients, client)
+	}
+	return m.federate(ctx, req, clients, acceptableIntents, failed), nil
+}
+
+// endpointClient returns the client for an endpoint called by name, if it
+// exists and handles one of the acceptable intents
+func (m *Manager) endpointClient(name string, acceptableIntents []string) (*Client, error) {
+	client := m.pool.GetClient(name)
+	if client == nil {
+		if admission := m.pool.GetAdmission(name); !admission.Admitted() {
+			return nil, fmt.Errorf("endpoint '%s' was refused by admission control: %s",
+				name, strings.Join(admission.Reasons, "; "))
+		}
+		return nil, fmt.Errorf("endpoint '%s' not found", name)
+	}
+	if len(acceptableIntents) > 0 && len(endpointIntents(&client.config, acceptableIntents)) == 0 {
+		return nil, fmt.Errorf("endpoint '%s' handles none of the applicable intents %v", name, acceptableIntents)
+	}
+	return client, nil
+}
+
+// federate calls the given endpoints through the privacy gate, then validates,
+// checks, merges, filters and audits their mutations. Results already known,
+// such as endpoints that could not be called, are included in the response.
+func (m *Manager) federate(ctx context.Context, req *pb.RTBRequest, clients []*Client, acceptableIntents []string, allResults []FederatedResult) *FederatedResponse {
+	startTime := time.Now()
+	config := m.Config()
+
+	// Endpoints the privacy signals do not permit are skipped, and the rest
+	// receive the scrubbed request
+	if m.privacy != nil {
+		assessment := m.privacy.Assess(req)
+		var permitted []*Client
+		for _, c := range clients {
+			decision := m.privacy.Allow(assessment, privacy.KindEndpoint, c.config.Name)
+			if decision.Allowed {
+				permitted = append(permitted, c)
+				continue
+			}
+			allResults = append(allResults, FederatedResult{
+				EndpointName: c.config.Name,
+				Privacy:      &decision,
+				Error:        "blocked by privacy gate: " + decision.Reason,
+			})
+		}
+		clients = permitted
+		req = privacy.Scrub(req, assessment)
+	}
 
 	if len(clients) == 0 {
 		log.Printf("[Federation] No healthy endpoints available for request %s", req.GetId())
 		return &FederatedResponse{
-			ID:             req.GetId(),
-			Mutations:      nil,
-			TotalLatencyMs: time.Since(startTime).Milliseconds(),
-		}, nil
+			ID:              req.GetId(),
+			Mutations:       nil,
+			EndpointResults: allResults,
+			TotalLatencyMs:  time.Since(startTime).Milliseconds(),
+		}
 	}
 
 	// Sort clients by priority
@@ -127,24 +224,42 @@ func (m *Manager) GetMutations(ctx context.Context, req *pb.RTBRequest, acceptab
 	priorityGroups := groupByPriority(clients)
 
 	var allMutations []*pb.Mutation
-	var allResults []FederatedResult
-
-	// Execute priority groups sequentially, endpoints within group in parallel
-	for _, group := range priorityGroups {
-		groupMutations, groupResults := m.executeGroup(ctx, req, group)
-		allMutations = append(allMutations, groupMutations...)
+	var conflicts []ConflictResolution
+	trail := m.newAuditTrail(req)
+
+	// Execute priority groups sequentially, endpoints within group in parallel.
+	// In pipeline mode each group sees the request with earlier groups' mutations applied.
+	stageReq := req
+	for i, group := range priorityGroups {
+		groupResults := m.executeGroup(ctx, config, stageReq, group, acceptableIntents)
+		trail.add(groupResults)
 		allResults = append(allResults, groupResults...)
+		if !config.Pipeline {
+			continue
+		}
+		groupMutations, groupConflicts := m.resolve(config, groupResults, trail)
+		conflicts = append(conflicts, groupConflicts...)
+		groupMutations = trail.filterIntents(groupMutations, acceptableIntents)
+		stageReq, groupMutations = applyStage(stageReq, groupMutations, trail)
+		log.Printf("[Federation] Request %s pipeline stage %d applied %d mutations",
+			req.GetId(), i+1, len(groupMutations))
+		allMutations = append(allMutations, groupMutations...)
 	}
 
-	// Filter mutations by acceptable intents if specified
-	if len(acceptableIntents) > 0 {
-		allMutations = filterMutationsByIntent(allMutations, acceptableIntents)
+	// Merge contradictory mutations across all groups
+	if !config.Pipeline {
+		allMutations, conflicts = m.resolve(config, allResults, trail)
 	}
 
+	// Filter mutations by acceptable intents if specified
+	allMutations = trail.filterIntents(allMutations, acceptableIntents)
+	m.auditAccepted(trail, allMutations)
+
 	response := &FederatedResponse{
 		ID:              req.GetId(),
 		Mutations:       allMutations,
 		EndpointResults: allResults,
+		Conflicts:       conflicts,
 		TotalLatencyMs:  time.Since(startTime).Milliseconds(),
 		Metadata: &pb.Metadata{
 			ApiVersion:   stringPtr("1.0"),
@@ -155,11 +270,64 @@ func (m *Manager) GetMutations(ctx context.Context, req *pb.RTBRequest, acceptab
 	log.Printf("[Federation] Request %s completed in %dms, %d mutations from %d endpoints",
 		req.GetId(), response.TotalLatencyMs, len(allMutations), len(allResults))
 
-	return response, nil
+	return response
+}
+
+// resolve returns the mutations of the successful results, with contradictory
+// mutations merged using the configured per-intent strategies
+func (m *Manager) resolve(config *Config, results []FederatedResult, trail *auditTrail) ([]*pb.Mutation, []ConflictResolution) {
+	if len(config.ConflictResolution) == 0 {
+		var mutations []*pb.Mutation
+		for _, r := range results {
+			if r.Success {
+				mutations = append(mutations, r.Mutations...)
+			}
+		}
+		return mutations, nil
+	}
+	proposals, conflicts := resolveConflicts(config, rankResults(config, results))
+	var resolved []*pb.Mutation
+	for _, p := range proposals {
+		reason := fmt.Sprintf("%s resolved the conflict in favour of '%s'", p.strategy, p.lostTo)
+		switch {
+		case p.dropped:
+			trail.reject(p.proposed, audit.StageConflict, "conflict", reason)
+			continue
+		case p.removed != nil:
+			trail.split(p.mutation, p.removed, p.proposed, audit.StageConflict, "conflict", reason)
+		default:
+			trail.derive(p.mutation, p.proposed)
+		}
+		resolved = append(resolved, p.mutation)
+	}
+	for _, c := range conflicts {
+		log.Printf("[Federation] Resolved %s conflict on %s using %s, winner '%s' of %v",
+			c.Intent, c.Target, c.Strategy, c.Winner, c.Candidates)
+	}
+	return resolved, conflicts
+}
+
+// applyStage applies a pipeline stage's mutations to a copy of the request.
+// It returns the request for the next stage and the mutations that were applied;
+// mutations the patch engine rejects are dropped.
+func applyStage(req *pb.RTBRequest, mutations []*pb.Mutation, trail *auditTrail) (*pb.RTBRequest, []*pb.Mutation) {
+	if len(mutations) == 0 {
+		return req, nil
+	}
+	next := proto.Clone(req).(*pb.RTBRequest)
+	results := patch.NewApplier().Apply(next.GetBidRequest(), next.GetBidResponse(), mutations)
+	for _, r := range results {
+		if !r.Accepted {
+			log.Printf("[Federation] Pipeline dropped %v mutation on %s: %s: %s",
+				r.Mutation.GetIntent(), r.Mutation.GetPath(), r.Reason, r.Detail)
+			trail.reject(r.Mutation, audit.StagePipeline, string(r.Reason), r.Detail)
+		}
+	}
+	return next, patch.Accepted(results)
 }
 
 // executeGroup executes all clients in a priority group in parallel
-func (m *Manager) executeGroup(ctx context.Context, req *pb.RTBRequest, clients []*Client) ([]*pb.Mutation, []FederatedResult) {
+func (m *Manager) executeGroup(ctx context.Context, config *Config, req *pb.RTBRequest, clients []*Client, acceptableIntents []string) []FederatedResult {
 	var wg sync.WaitGroup
 	resultChan := make(chan FederatedResult, len(clients))
 
@@ -167,7 +335,7 @@ func (m *Manager) executeGroup(ctx context.Context, req *pb.RTBRequest, clients
 		wg.Add(1)
 		go func(c *Client) {
 			defer wg.Done()
-			result := m.executeClient(ctx, req, c)
+			result := m.executeClient(ctx, config, req, c, acceptableIntents)
 			resultChan <- result
 		}(client)
 	}
@@ -178,30 +346,35 @@ func (m *Manager) executeGroup(ctx context.Context, req *pb.RTBRequest, clients
 		close(resultChan)
 	}()
 
-	var mutations []*pb.Mutation
 	var results []FederatedResult
-
 	for result := range resultChan {
 		results = append(results, result)
-		if result.Success {
-			mutations = append(mutations, result.Mutations...)
-		}
 	}
 
-	return mutations, results
+	return results
 }
 
 // executeClient executes a single client call
-func (m *Manager) executeClient(ctx context.Context, req *pb.RTBRequest, client *Client) FederatedResult {
+func (m *Manager) executeClient(ctx context.Context, config *Config, req *pb.RTBRequest, client *Client, acceptableIntents []string) FederatedResult {
 	startTime := time.Now()
 	result := FederatedResult{
 		EndpointName: client.config.Name,
 	}
 
-	resp, err := client.GetMutations(ctx, req)
-	result.LatencyMs = time.Since(startTime).Milliseconds()
+	ctx, span := tracing.Tracer().Start(ctx, "Manager.executeClient",
+		trace.WithAttributes(append(tracing.RequestAttributes(req),
+			attribute.String("artf.endpoint", client.config.Name))...))
+	defer span.End()
+
+	// Each endpoint receives its own copy of the request, scoped to the
+	// intents it may return and the fields it is allowed to see
+	resp, violations, err := client.getMutations(ctx, endpointRequest(config, req, client, acceptableIntents))
+	latency := time.Since(startTime)
+	result.LatencyMs = latency.Milliseconds()
+	m.metrics.ObserveEndpoint(client.config.Name, err == nil, latency)
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		result.Success = false
 		result.Error = err.Error()
 		log.Printf("[Federation] Endpoint '%s' failed in %dms: %v",
@@ -209,30 +382,213 @@ func (m *Manager) executeClient(ctx context.Context, req *pb.RTBRequest, client
 	} else {
 		result.Success = true
 		result.Mutations = resp.GetMutations()
-		log.Printf("[Federation] Endpoint '%s' returned %d mutations in %dms",
-			client.config.Name, len(result.Mutations), result.LatencyMs)
+		result.DroppedMutations = len(violations)
+		log.Printf("[Federation] Endpoint '%s' returned %d mutations (%d dropped) in %dms",
+			client.config.Name, len(result.Mutations), result.DroppedMutations, result.LatencyMs)
+
+		// Let the orchestrator policy decide which mutations are applied
+		proposed := result.Mutations
+		if m.policy != nil {
+			result.Mutations, result.PolicyDecisions = m.policy.Evaluate(client.config.Name, req, result.Mutations)
+			for _, d := range result.PolicyDecisions {
+				if !d.Accepted {
+					log.Printf("[Federation] Policy rejected %s from '%s' (%s): %s",
+						d.Intent, client.config.Name, d.Rule, d.Reason)
+				}
+			}
+		}
+		m.auditResult(req, client.config.Name, proposed, violations, result.PolicyDecisions)
+		span.SetAttributes(
+			attribute.Int("artf.mutations.proposed", len(proposed)),
+			attribute.Int("artf.mutations.accepted", len(result.Mutations)),
+			attribute.Int("artf.mutations.dropped", result.DroppedMutations),
+		)
 	}
 
 	return result
 }
 
-// CallEndpoint calls a specific endpoint by name
-func (m *Manager) CallEndpoint(ctx context.Context, endpointName string, req *pb.RTBRequest) (*pb.RTBResponse, error) {
-	client := m.pool.GetClient(endpointName)
-	if client == nil {
-		return nil, fmt.Errorf("endpoint '%s' not found", endpointName)
+// auditResult records the mutations an endpoint proposed that failed
+// validation or policy. Decisions are aligned with the conforming mutations
+// they were made on. Accepted mutations are audited by the request's trail
+// once their final decision is known.
+func (m *Manager) auditResult(req *pb.RTBRequest, endpoint string, proposed []*pb.Mutation, violations []validate.Violation, decisions []PolicyDecision) {
+	if m.audit == nil {
+		return
+	}
+	records := make([]audit.Record, 0, len(violations))
+	for _, v := range violations {
+		records = append(records, audit.NewRecord(req, audit.SourceEndpoint, endpoint, v.Mutation, false, audit.StageValidation,
+			string(v.Reason), v.Detail))
 	}
+	for i, mut := range proposed {
+		if i < len(decisions) && !decisions[i].Accepted {
+			d := decisions[i]
+			records = append(records, audit.NewRecord(req, audit.SourceEndpoint, endpoint, mut, false, audit.StagePolicy, d.Rule, d.Reason))
+		}
+	}
+	m.audit.Log(records...)
+}
+
+// mutationOrigin is the endpoint and proposed mutation a federated mutation derives from
+type mutationOrigin struct {
+	endpoint string
+	proposed *pb.Mutation
+}
+
+// auditTrail follows the mutations that passed validation and policy through
+// conflict resolution, the pipeline and intent filtering, so each is audited
+// once with its final decision. A nil trail records nothing.
+type auditTrail struct {
+	req     *pb.RTBRequest
+	origins map[*pb.Mutation]mutationOrigin
+	records []audit.Record
+}
+
+// newAuditTrail creates the trail for a request, or nil if nothing is audited
+func (m *Manager) newAuditTrail(req *pb.RTBRequest) *auditTrail {
+	if m.audit == nil {
+		return nil
+	}
+	return &auditTrail{req: req, origins: make(map[*pb.Mutation]mutationOrigin)}
+}
+
+// add starts following the accepted mutations of the given results
+func (t *auditTrail) add(results []FederatedResult) {
+	if t == nil {
+		return
+	}
+	for _, r := range results {
+		if !r.Success {
+			continue
+		}
+		for _, mut := range r.Mutations {
+			t.origins[mut] = mutationOrigin{endpoint: r.EndpointName, proposed: mut}
+		}
+	}
+}
+
+// derive follows a mutation produced from another, such as a merged value
+func (t *auditTrail) derive(mut, from *pb.Mutation) {
+	if t == nil || mut == from {
+		return
+	}
+	t.origins[mut] = t.origins[from]
+}
+
+// split follows the kept part of a mutation whose other part, such as some of
+// its deal IDs, was dropped at a later stage. Each part is audited once, as
+// proposed by the mutation's endpoint.
+func (t *auditTrail) split(kept, dropped, from *pb.Mutation, stage, code, reason string) {
+	if t == nil {
+		return
+	}
+	origin, ok := t.origins[from]
+	if !ok {
+		return
+	}
+	delete(t.origins, from)
+	t.origins[kept] = mutationOrigin{endpoint: origin.endpoint, proposed: kept}
+	t.records = append(t.records, audit.NewRecord(t.req, audit.SourceEndpoint, origin.endpoint, dropped, false, stage, code, reason))
+}
+
+// reject records that a followed mutation was dropped at a later stage
+func (t *auditTrail) reject(mut *pb.Mutation, stage, code, reason string) {
+	if t == nil {
+		return
+	}
+	origin, ok := t.origins[mut]
+	if !ok {
+		return
+	}
+	delete(t.origins, mut)
+	t.records = append(t.records, audit.NewRecord(t.req, audit.SourceEndpoint, origin.endpoint, origin.proposed, false, stage, code, reason))
+}
 
-	return client.GetMutations(ctx, req)
+// filterIntents keeps the mutations for acceptable intents and rejects the rest
+func (t *auditTrail) filterIntents(mutations []*pb.Mutation, acceptableIntents []string) []*pb.Mutation {
+	filtered := filterMutationsByIntent(mutations, acceptableIntents)
+	if t == nil || len(filtered) == len(mutations) {
+		return filtered
+	}
+	kept := make(map[*pb.Mutation]bool, len(filtered))
+	for _, mut := range filtered {
+		kept[mut] = true
+	}
+	for _, mut := range mutations {
+		if !kept[mut] {
+			t.reject(mut, audit.StageIntent, "intent_not_acceptable",
+				fmt.Sprintf("%v is not an acceptable intent", mut.GetIntent()))
+		}
+	}
+	return filtered
+}
+
+// auditAccepted logs the trail, recording the returned mutations as accepted
+// at the last stage that checked them before the trail
+func (m *Manager) auditAccepted(t *auditTrail, returned []*pb.Mutation) {
+	if t == nil {
+		return
+	}
+	stage := audit.StageValidation
+	if m.policy != nil {
+		stage = audit.StagePolicy
+	}
+	for _, mut := range returned {
+		if origin, ok := t.origins[mut]; ok {
+			t.records = append(t.records, audit.NewRecord(t.req, audit.SourceEndpoint, origin.endpoint, origin.proposed, true, stage, "", ""))
+		}
+	}
+	m.audit.Log(t.records...)
+}
+
+// SetAuditLogger sets the logger that records every mutation proposed by an endpoint
+func (m *Manager) SetAuditLogger(logger *audit.Logger) {
+	m.audit = logger
+}
+
+// SetMetrics sets the collector that records endpoint latencies
+func (m *Manager) SetMetrics(mt *metrics.Metrics) {
+	m.metrics = mt
+}
+
+// SetPrivacyGate sets the gate that decides which endpoints may receive a request
+func (m *Manager) SetPrivacyGate(gate *privacy.Gate) {
+	m.privacy = gate
+}
+
+// SetPolicy sets the policy engine used to accept or reject federated mutations
+func (m *Manager) SetPolicy(policy *PolicyEngine) {
+	m.policy = policy
+}
+
+// Policy returns the policy engine, or nil if none is configured
+func (m *Manager) Policy() *PolicyEngine {
+	return m.policy
 }
 
 // ListEndpoints returns information about all configured endpoints
 func (m *Manager) ListEndpoints() []EndpointInfo {
 	var endpoints []EndpointInfo
+	config := m.Config()
 
-	for _, ep := range m.config.Endpoints {
+	for _, ep := range config.Endpoints {
 		client := m.pool.GetClient(ep.Name)
 		healthy := client != nil && client.IsHealthy()
+		var dropped uint64
+		var probes []ProbeResult
+		var circuit string
+		var replicas []ReplicaInfo
+		var intentViolations uint64
+		var disabled string
+		if client != nil {
+			dropped = client.DroppedMutations().Total()
+			intentViolations = client.IntentViolations()
+			disabled = client.DisabledReason()
+			probes = client.ProbeHistory()
+			circuit = client.CircuitState()
+			replicas = client.Replicas()
+		}
 
 		info := EndpointInfo{
 			Name:              ep.Name,
@@ -243,7 +599,15 @@ func (m *Manager) ListEndpoints() []EndpointInfo {
 			Priority:          ep.Priority,
 			Enabled:           ep.IsEnabled(),
 			Healthy:           healthy,
-			TimeoutMs:         ep.GetTimeoutMs(m.config.Defaults),
+			CircuitState:      circuit,
+			Replicas:          replicas,
+			AllowedFields:     ep.AllowedFields(),
+			Admission:         m.pool.GetAdmission(ep.Name),
+			TimeoutMs:         ep.GetTimeoutMs(config.Defaults),
+			DroppedMutations:  dropped,
+			IntentViolations:  intentViolations,
+			DisabledReason:    disabled,
+			ProbeHistory:      probes,
 		}
 		endpoints = append(endpoints, info)
 	}
@@ -253,13 +617,28 @@ func (m *Manager) ListEndpoints() []EndpointInfo {
 
 // GetEndpointInfo returns information about a specific endpoint
 func (m *Manager) GetEndpointInfo(name string) (*EndpointInfo, error) {
-	ep := m.config.GetEndpointByName(name)
+	config := m.Config()
+	ep := config.GetEndpointByName(name)
 	if ep == nil {
 		return nil, fmt.Errorf("endpoint '%s' not found", name)
 	}
 
 	client := m.pool.GetClient(name)
 	healthy := client != nil && client.IsHealthy()
+	var dropped uint64
+	var probes []ProbeResult
+	var circuit string
+	var replicas []ReplicaInfo
+	var intentViolations uint64
+	var disabled string
+	if client != nil {
+		dropped = client.DroppedMutations().Total()
+		intentViolations = client.IntentViolations()
+		disabled = client.DisabledReason()
+		probes = client.ProbeHistory()
+		circuit = client.CircuitState()
+		replicas = client.Replicas()
+	}
 
 	return &EndpointInfo{
 		Name:              ep.Name,
@@ -270,7 +649,15 @@ func (m *Manager) GetEndpointInfo(name string) (*EndpointInfo, error) {
 		Priority:          ep.Priority,
 		Enabled:           ep.IsEnabled(),
 		Healthy:           healthy,
-		TimeoutMs:         ep.GetTimeoutMs(m.config.Defaults),
+		CircuitState:      circuit,
+		Replicas:          replicas,
+		AllowedFields:     ep.AllowedFields(),
+		Admission:         m.pool.GetAdmission(ep.Name),
+		TimeoutMs:         ep.GetTimeoutMs(config.Defaults),
+		DroppedMutations:  dropped,
+		IntentViolations:  intentViolations,
+		DisabledReason:    disabled,
+		ProbeHistory:      probes,
 	}, nil
 }
 
@@ -284,8 +671,10 @@ func (m *Manager) Pool() *ClientPool {
 	return m.pool
 }
 
-// Config returns the federation configuration
+// Config returns the current federation configuration
 func (m *Manager) Config() *Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.config
 }
 
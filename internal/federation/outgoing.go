@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/protobuf/proto"
+)
+
+// endpointRequest returns the copy of the request sent to an endpoint. Its
+// applicable_intents are the intents both the caller and the endpoint accept,
+// its originator is this orchestrator if one is configured, and it only holds
+// the fields the endpoint is allowed to see.
+func endpointRequest(config *Config, req *pb.RTBRequest, client *Client, acceptableIntents []string) *pb.RTBRequest {
+	out := proto.Clone(req).(*pb.RTBRequest)
+	out.ApplicableIntents = endpointIntents(&client.config, acceptableIntents)
+	if config.Originator != nil {
+		out.Originator = config.Originator.originator()
+	}
+	client.redact(out)
+	return out
+}
+
+// endpointIntents returns the acceptable intents the endpoint handles, in the
+// caller's order. An empty caller list accepts the endpoint's own intents, and
+// an empty result means no restriction.
+func endpointIntents(ep *EndpointConfig, acceptableIntents []string) []pb.Intent {
+	names := acceptableIntents
+	if len(names) == 0 {
+		names = ep.ApplicableIntents
+	}
+
+	seen := make(map[pb.Intent]bool)
+	var intents []pb.Intent
+	for _, name := range names {
+		value, ok := pb.Intent_value[strings.ToUpper(name)]
+		if !ok || !ep.HasIntent(name) || seen[pb.Intent(value)] {
+			continue
+		}
+		seen[pb.Intent(value)] = true
+		intents = append(intents, pb.Intent(value))
+	}
+	return intents
+}
+
+// intentNames returns the names of the given intents
+func intentNames(intents []pb.Intent) []string {
+	names := make([]string, 0, len(intents))
+	for _, intent := range intents {
+		names = append(names, intent.String())
+	}
+	return names
+}
+
+// originator returns the configured originator as a protobuf message
+func (o *OriginatorConfig) originator() *pb.Originator {
+	originator := &pb.Originator{
+		Type: pb.Originator_Type(pb.Originator_Type_value[strings.ToUpper(o.Type)]).Enum(),
+	}
+	if o.ID != "" {
+		originator.Id = proto.String(o.ID)
+	}
+	return originator
+}
+
+// validate checks that the originator type is known
+func (o *OriginatorConfig) validate() error {
+	value, ok := pb.Originator_Type_value[strings.ToUpper(o.Type)]
+	if !ok || pb.Originator_Type(value) == pb.Originator_TYPE_UNSPECIFIED {
+		return fmt.Errorf("originator: invalid type '%s'", o.Type)
+	}
+	return nil
+}
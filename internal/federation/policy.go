@@ -0,0 +1,340 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy rule names reported in decisions
+const (
+	RuleMaxFloorIncrease  = "max_floor_increase_percent"
+	RuleMaxBidShade       = "max_bid_shade_percent"
+	RuleAllowedDealPrefix = "allowed_deal_prefixes"
+	RuleSegmentAllowlist  = "segment_allowlists"
+	RuleDenyIntents       = "deny_intents"
+)
+
+// PolicyConfig is the orchestrator policy that decides which federated mutations are applied
+type PolicyConfig struct {
+	// Version of the policy schema
+	Version string `json:"version" yaml:"version"`
+
+	// MaxFloorIncreasePercent caps how far ADJUST_DEAL_FLOOR and ADJUST_DEAL_MARGIN may raise a deal floor
+	MaxFloorIncreasePercent *float64 `json:"max_floor_increase_percent,omitempty" yaml:"max_floor_increase_percent,omitempty"`
+
+	// MaxBidShadePercent caps how far BID_SHADE may lower a bid price
+	MaxBidShadePercent *float64 `json:"max_bid_shade_percent,omitempty" yaml:"max_bid_shade_percent,omitempty"`
+
+	// AllowedDealPrefixes restricts ACTIVATE_DEALS to deal IDs with one of these prefixes.
+	// If empty, any deal ID may be activated.
+	AllowedDealPrefixes []string `json:"allowed_deal_prefixes,omitempty" yaml:"allowed_deal_prefixes,omitempty"`
+
+	// SegmentAllowlists maps an endpoint name to the segment IDs it may activate.
+	// Endpoints without an entry may activate any segment.
+	SegmentAllowlists map[string][]string `json:"segment_allowlists,omitempty" yaml:"segment_allowlists,omitempty"`
+
+	// DenyIntents rejects intents for requests from the given originator types
+	DenyIntents []DenyIntentRule `json:"deny_intents,omitempty" yaml:"deny_intents,omitempty"`
+}
+
+// DenyIntentRule rejects the listed intents when the request originator matches
+type DenyIntentRule struct {
+	// Originator is the originator type (PUBLISHER, SSP, EXCHANGE, DSP)
+	Originator string `json:"originator" yaml:"originator"`
+
+	// Intents are the intent names to reject
+	Intents []string `json:"intents" yaml:"intents"`
+}
+
+// PolicyDecision records whether a mutation was accepted by the policy and why
+type PolicyDecision struct {
+	Intent   string `json:"intent"`
+	Path     string `json:"path"`
+	Accepted bool   `json:"accepted"`
+	Rule     string `json:"rule,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// PolicyEngine evaluates federated mutations against a PolicyConfig
+type PolicyEngine struct {
+	config *PolicyConfig
+}
+
+// LoadPolicyConfig loads a policy configuration from a file
+func LoadPolicyConfig(path string) (*PolicyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	return ParsePolicyConfig(data, path)
+}
+
+// ParsePolicyConfig parses a policy configuration from bytes
+func ParsePolicyConfig(data []byte, filename string) (*PolicyConfig, error) {
+	var config PolicyConfig
+
+	if strings.HasSuffix(filename, ".json") {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON policy: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML policy: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Validate checks the policy configuration for errors
+func (c *PolicyConfig) Validate() error {
+	if c.MaxFloorIncreasePercent != nil && *c.MaxFloorIncreasePercent < 0 {
+		return fmt.Errorf("%s must not be negative", RuleMaxFloorIncrease)
+	}
+	if c.MaxBidShadePercent != nil && (*c.MaxBidShadePercent < 0 || *c.MaxBidShadePercent > 100) {
+		return fmt.Errorf("%s must be between 0 and 100", RuleMaxBidShade)
+	}
+	for i, rule := range c.DenyIntents {
+		if _, ok := pb.Originator_Type_value["TYPE_"+strings.ToUpper(rule.Originator)]; !ok {
+			return fmt.Errorf("deny_intents %d: invalid originator '%s'", i, rule.Originator)
+		}
+		for _, intent := range rule.Intents {
+			if !isValidIntent(intent) {
+				return fmt.Errorf("deny_intents %d: invalid intent '%s'", i, intent)
+			}
+		}
+	}
+	return nil
+}
+
+// NewPolicyEngine creates a policy engine for the given configuration
+func NewPolicyEngine(config *PolicyConfig) *PolicyEngine {
+	return &PolicyEngine{config: config}
+}
+
+// NewPolicyEngineFromFile loads a policy file and creates an engine
+func NewPolicyEngineFromFile(path string) (*PolicyEngine, error) {
+	config, err := LoadPolicyConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicyEngine(config), nil
+}
+
+// Config returns the policy configuration
+func (e *PolicyEngine) Config() *PolicyConfig {
+	return e.config
+}
+
+// Evaluate decides on each mutation proposed by an endpoint.
+// It returns the accepted mutations in order and a decision for every mutation.
+func (e *PolicyEngine) Evaluate(endpoint string, req *pb.RTBRequest, mutations []*pb.Mutation) ([]*pb.Mutation, []PolicyDecision) {
+	var accepted []*pb.Mutation
+	decisions := make([]PolicyDecision, 0, len(mutations))
+	for _, m := range mutations {
+		decision := PolicyDecision{
+			Intent:   m.GetIntent().String(),
+			Path:     m.GetPath(),
+			Accepted: true,
+		}
+		if rule, reason := e.check(endpoint, req, m); rule != "" {
+			decision.Accepted = false
+			decision.Rule = rule
+			decision.Reason = reason
+		} else {
+			accepted = append(accepted, m)
+		}
+		decisions = append(decisions, decision)
+	}
+	return accepted, decisions
+}
+
+// check returns the rule and reason that reject a mutation, or empty strings if it is accepted
+func (e *PolicyEngine) check(endpoint string, req *pb.RTBRequest, m *pb.Mutation) (string, string) {
+	if reason := e.checkDenyIntents(req, m); reason != "" {
+		return RuleDenyIntents, reason
+	}
+
+	switch m.GetIntent() {
+	case pb.Intent_ACTIVATE_SEGMENTS:
+		if reason := e.checkSegmentAllowlist(endpoint, m); reason != "" {
+			return RuleSegmentAllowlist, reason
+		}
+	case pb.Intent_ACTIVATE_DEALS:
+		if reason := e.checkDealPrefixes(m); reason != "" {
+			return RuleAllowedDealPrefix, reason
+		}
+	case pb.Intent_ADJUST_DEAL_FLOOR, pb.Intent_ADJUST_DEAL_MARGIN:
+		if reason := e.checkFloorIncrease(req.GetBidRequest(), m); reason != "" {
+			return RuleMaxFloorIncrease, reason
+		}
+	case pb.Intent_BID_SHADE:
+		if reason := e.checkBidShade(req.GetBidResponse(), m); reason != "" {
+			return RuleMaxBidShade, reason
+		}
+	}
+	return "", ""
+}
+
+// checkDenyIntents rejects intents denied for the request originator type
+func (e *PolicyEngine) checkDenyIntents(req *pb.RTBRequest, m *pb.Mutation) string {
+	originator := strings.TrimPrefix(req.GetOriginator().GetType().String(), "TYPE_")
+	for _, rule := range e.config.DenyIntents {
+		if !strings.EqualFold(rule.Originator, originator) {
+			continue
+		}
+		for _, intent := range rule.Intents {
+			if strings.EqualFold(intent, m.GetIntent().String()) {
+				return fmt.Sprintf("%v is not allowed for %s-originated requests", m.GetIntent(), originator)
+			}
+		}
+	}
+	return ""
+}
+
+// checkSegmentAllowlist rejects segments not on the endpoint's allowlist
+func (e *PolicyEngine) checkSegmentAllowlist(endpoint string, m *pb.Mutation) string {
+	allowlist, ok := e.config.SegmentAllowlists[endpoint]
+	if !ok {
+		return ""
+	}
+	allowed := make(map[string]bool, len(allowlist))
+	for _, id := range allowlist {
+		allowed[id] = true
+	}
+	for _, id := range m.GetIds().GetId() {
+		if !allowed[id] {
+			return fmt.Sprintf("segment '%s' is not on the allowlist for endpoint '%s'", id, endpoint)
+		}
+	}
+	return ""
+}
+
+// checkDealPrefixes rejects deal IDs without an allowed prefix
+func (e *PolicyEngine) checkDealPrefixes(m *pb.Mutation) string {
+	if len(e.config.AllowedDealPrefixes) == 0 {
+		return ""
+	}
+	for _, id := range m.GetIds().GetId() {
+		allowed := false
+		for _, prefix := range e.config.AllowedDealPrefixes {
+			if strings.HasPrefix(id, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("deal '%s' does not match an allowed prefix", id)
+		}
+	}
+	return ""
+}
+
+// checkFloorIncrease rejects floor changes above the configured percentage.
+// Deals without a positive current floor cannot be compared and are not limited.
+func (e *PolicyEngine) checkFloorIncrease(req *openrtb.BidRequest, m *pb.Mutation) string {
+	if e.config.MaxFloorIncreasePercent == nil {
+		return ""
+	}
+	limit := *e.config.MaxFloorIncreasePercent
+
+	for _, deal := range targetDeals(req, m.GetPath()) {
+		current := deal.GetBidfloor()
+		if current <= 0 {
+			continue
+		}
+		proposed := proposedFloor(current, m)
+		increase := (proposed - current) / current * 100
+		if increase > limit {
+			return fmt.Sprintf("floor increase of %.1f%% on deal '%s' exceeds %.1f%%", increase, deal.GetId(), limit)
+		}
+	}
+	return ""
+}
+
+// checkBidShade rejects price reductions above the configured percentage
+func (e *PolicyEngine) checkBidShade(resp *openrtb.BidResponse, m *pb.Mutation) string {
+	if e.config.MaxBidShadePercent == nil {
+		return ""
+	}
+	limit := *e.config.MaxBidShadePercent
+
+	path, err := patch.ParsePath(m.GetPath())
+	if err != nil || path.Kind != patch.PathBid {
+		return ""
+	}
+	for _, seatbid := range resp.GetSeatbid() {
+		if seatbid.GetSeat() != path.Seat {
+			continue
+		}
+		for _, bid := range seatbid.GetBid() {
+			if bid.GetId() != path.BidID || bid.GetPrice() <= 0 {
+				continue
+			}
+			shade := (bid.GetPrice() - m.GetAdjustBid().GetPrice()) / bid.GetPrice() * 100
+			if shade > limit {
+				return fmt.Sprintf("bid shade of %.1f%% on bid '%s' exceeds %.1f%%", shade, bid.GetId(), limit)
+			}
+		}
+	}
+	return ""
+}
+
+// targetDeals returns the deals in the request addressed by a deal path
+func targetDeals(req *openrtb.BidRequest, p string) []*openrtb.BidRequest_Imp_Pmp_Deal {
+	path, err := patch.ParsePath(p)
+	if err != nil {
+		return nil
+	}
+	var deals []*openrtb.BidRequest_Imp_Pmp_Deal
+	for _, imp := range req.GetImp() {
+		if imp.GetId() != path.ImpID {
+			continue
+		}
+		for _, deal := range imp.GetPmp().GetDeals() {
+			if path.Kind == patch.PathImpDeals || (path.Kind == patch.PathDeal && deal.GetId() == path.DealID) {
+				deals = append(deals, deal)
+			}
+		}
+	}
+	return deals
+}
+
+// proposedFloor returns the floor a deal would have after the mutation is applied
+func proposedFloor(current float64, m *pb.Mutation) float64 {
+	if m.GetIntent() == pb.Intent_ADJUST_DEAL_FLOOR {
+		return m.GetAdjustDeal().GetBidfloor()
+	}
+	margin := m.GetAdjustDeal().GetMargin()
+	if margin.GetCalculationType() == pb.Margin_PERCENT {
+		return current * (1 + margin.GetValue()/100)
+	}
+	return current + margin.GetValue()
+}
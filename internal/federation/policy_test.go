@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"testing"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"google.golang.org/protobuf/proto"
+)
+
+// policyRequest returns an SSP-originated request with a deal floored at 2
+// and a bid priced at 10
+func policyRequest() *pb.RTBRequest {
+	return &pb.RTBRequest{
+		Id:         proto.String("req-1"),
+		Originator: &pb.Originator{Type: pb.Originator_TYPE_SSP.Enum()},
+		BidRequest: &openrtb.BidRequest{
+			Id: proto.String("req-1"),
+			Imp: []*openrtb.BidRequest_Imp{{
+				Id: proto.String("1"),
+				Pmp: &openrtb.BidRequest_Imp_Pmp{Deals: []*openrtb.BidRequest_Imp_Pmp_Deal{
+					{Id: proto.String("deal-a"), Bidfloor: proto.Float64(2)},
+					{Id: proto.String("deal-free")},
+				}},
+			}},
+		},
+		BidResponse: &openrtb.BidResponse{
+			Id: proto.String("req-1"),
+			Seatbid: []*openrtb.BidResponse_SeatBid{{
+				Seat: proto.String("seat-1"),
+				Bid:  []*openrtb.BidResponse_SeatBid_Bid{{Id: proto.String("bid-1"), Price: proto.Float64(10)}},
+			}},
+		},
+	}
+}
+
+func idsMutation(intent pb.Intent, op pb.Operation, path string, ids ...string) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: intent.Enum(),
+		Op:     op.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_Ids{Ids: &pb.IDsPayload{Id: ids}},
+	}
+}
+
+func segmentsMutation(ids ...string) *pb.Mutation {
+	return idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", ids...)
+}
+
+func dealsMutation(ids ...string) *pb.Mutation {
+	return idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1", ids...)
+}
+
+func floorMutation(path string, floor float64) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADJUST_DEAL_FLOOR.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{Bidfloor: proto.Float64(floor)}},
+	}
+}
+
+func marginMutation(path string, value float64, calc pb.Margin_CalculationType) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADJUST_DEAL_MARGIN.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value: &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{
+			Margin: &pb.Margin{Value: proto.Float64(value), CalculationType: calc.Enum()},
+		}},
+	}
+}
+
+func shadeMutation(price float64) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_BID_SHADE.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String("/seatbid/seat-1/bid/bid-1"),
+		Value:  &pb.Mutation_AdjustBid{AdjustBid: &pb.AdjustBidPayload{Price: proto.Float64(price)}},
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy := &PolicyConfig{
+		MaxFloorIncreasePercent: proto.Float64(50),
+		MaxBidShadePercent:      proto.Float64(20),
+		AllowedDealPrefixes:     []string{"pub-", "ssp-"},
+		SegmentAllowlists: map[string][]string{
+			"audience-agent": {"seg-1", "seg-2"},
+		},
+		DenyIntents: []DenyIntentRule{
+			{Originator: "dsp", Intents: []string{"ACTIVATE_DEALS"}},
+			{Originator: "SSP", Intents: []string{"add_metrics"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		endpoint   string
+		originator pb.Originator_Type
+		mutation   *pb.Mutation
+		want       string
+	}{
+		// Intents without a configured rule are accepted by default
+		{"no rule for intent", "any", pb.Originator_TYPE_SSP, idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", "deal-a"), ""},
+
+		// Intent and originator selectors, matched case-insensitively
+		{"intent denied for originator", "any", pb.Originator_TYPE_SSP, &pb.Mutation{Intent: pb.Intent_ADD_METRICS.Enum()}, RuleDenyIntents},
+		{"intent denied for other originator", "any", pb.Originator_TYPE_DSP, dealsMutation("pub-1"), RuleDenyIntents},
+		{"intent allowed for this originator", "any", pb.Originator_TYPE_SSP, dealsMutation("pub-1"), ""},
+
+		// Deny rules take precedence over the intent's own rules
+		{"deny before deal prefixes", "any", pb.Originator_TYPE_DSP, dealsMutation("other-1"), RuleDenyIntents},
+
+		// Endpoint selectors
+		{"segment on endpoint allowlist", "audience-agent", pb.Originator_TYPE_SSP, segmentsMutation("seg-1", "seg-2"), ""},
+		{"segment off endpoint allowlist", "audience-agent", pb.Originator_TYPE_SSP, segmentsMutation("seg-1", "seg-9"), RuleSegmentAllowlist},
+		{"endpoint without allowlist", "other-agent", pb.Originator_TYPE_SSP, segmentsMutation("seg-9"), ""},
+
+		{"deal with allowed prefix", "any", pb.Originator_TYPE_SSP, dealsMutation("pub-1", "ssp-2"), ""},
+		{"deal without allowed prefix", "any", pb.Originator_TYPE_SSP, dealsMutation("pub-1", "other-1"), RuleAllowedDealPrefix},
+
+		{"floor increase within limit", "any", pb.Originator_TYPE_SSP, floorMutation("/imp/1/pmp/deals/deal-a", 3), ""},
+		{"floor increase above limit", "any", pb.Originator_TYPE_SSP, floorMutation("/imp/1/pmp/deals/deal-a", 3.5), RuleMaxFloorIncrease},
+		{"floor decrease", "any", pb.Originator_TYPE_SSP, floorMutation("/imp/1/pmp/deals/deal-a", 0.5), ""},
+		{"deal without floor is not limited", "any", pb.Originator_TYPE_SSP, floorMutation("/imp/1/pmp/deals/deal-free", 100), ""},
+		{"floor increase on every deal", "any", pb.Originator_TYPE_SSP, floorMutation("/imp/1/pmp/deals", 5), RuleMaxFloorIncrease},
+		{"percent margin within limit", "any", pb.Originator_TYPE_SSP, marginMutation("/imp/1/pmp/deals/deal-a", 50, pb.Margin_PERCENT), ""},
+		{"cpm margin above limit", "any", pb.Originator_TYPE_SSP, marginMutation("/imp/1/pmp/deals/deal-a", 1.5, pb.Margin_CPM), RuleMaxFloorIncrease},
+
+		{"bid shade within limit", "any", pb.Originator_TYPE_SSP, shadeMutation(8), ""},
+		{"bid shade above limit", "any", pb.Originator_TYPE_SSP, shadeMutation(7.5), RuleMaxBidShade},
+	}
+
+	engine := NewPolicyEngine(policy)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := policyRequest()
+			req.Originator.Type = tt.originator.Enum()
+			accepted, decisions := engine.Evaluate(tt.endpoint, req, []*pb.Mutation{tt.mutation})
+			if len(decisions) != 1 {
+				t.Fatalf("got %d decisions, want 1", len(decisions))
+			}
+			d := decisions[0]
+			if d.Rule != tt.want {
+				t.Errorf("rule = %q (%s), want %q", d.Rule, d.Reason, tt.want)
+			}
+			wantAccepted := tt.want == ""
+			if d.Accepted != wantAccepted || (len(accepted) == 1) != wantAccepted {
+				t.Errorf("accepted = %v with %d mutations returned, want %v", d.Accepted, len(accepted), wantAccepted)
+			}
+		})
+	}
+}
+
+func TestPolicyEvaluateDefault(t *testing.T) {
+	// An empty policy accepts everything
+	mutations := []*pb.Mutation{
+		segmentsMutation("seg-9"),
+		dealsMutation("other-1"),
+		floorMutation("/imp/1/pmp/deals/deal-a", 100),
+		shadeMutation(0.01),
+	}
+	accepted, decisions := NewPolicyEngine(&PolicyConfig{}).Evaluate("any", policyRequest(), mutations)
+	if len(accepted) != len(mutations) {
+		t.Errorf("accepted %d of %d mutations", len(accepted), len(mutations))
+	}
+	for i, d := range decisions {
+		if !d.Accepted || d.Rule != "" {
+			t.Errorf("decision %d = %+v, want accepted", i, d)
+		}
+	}
+}
+
+func TestPolicyEvaluateOrder(t *testing.T) {
+	policy := &PolicyConfig{AllowedDealPrefixes: []string{"pub-"}}
+	mutations := []*pb.Mutation{
+		dealsMutation("pub-1"),
+		dealsMutation("other-1"),
+		dealsMutation("pub-2"),
+	}
+	accepted, decisions := NewPolicyEngine(policy).Evaluate("any", policyRequest(), mutations)
+	if len(accepted) != 2 || accepted[0] != mutations[0] || accepted[1] != mutations[2] {
+		t.Errorf("accepted %v, want mutations 0 and 2 in order", accepted)
+	}
+	if len(decisions) != 3 || !decisions[0].Accepted || decisions[1].Accepted || !decisions[2].Accepted {
+		t.Errorf("decisions = %+v, want one per mutation in order", decisions)
+	}
+	if decisions[1].Path != "/imp/1" || decisions[1].Intent != "ACTIVATE_DEALS" {
+		t.Errorf("decision 1 = %+v, want the rejected mutation's intent and path", decisions[1])
+	}
+}
+
+func TestParsePolicyConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		wantErr  bool
+	}{
+		{"yaml", "policy.yaml", "max_bid_shade_percent: 20\ndeny_intents:\n  - originator: dsp\n    intents: [ACTIVATE_DEALS]\n", false},
+		{"json", "policy.json", `{"max_floor_increase_percent": 10, "allowed_deal_prefixes": ["pub-"]}`, false},
+		{"negative floor increase", "policy.yaml", "max_floor_increase_percent: -1\n", true},
+		{"bid shade above 100", "policy.yaml", "max_bid_shade_percent: 101\n", true},
+		{"unknown originator", "policy.yaml", "deny_intents:\n  - originator: buyer\n    intents: [BID_SHADE]\n", true},
+		{"unknown intent", "policy.yaml", "deny_intents:\n  - originator: ssp\n    intents: [RAISE_FLOORS]\n", true},
+		{"malformed json", "policy.json", `{"max_bid_shade_percent": }`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicyConfig([]byte(tt.data), tt.filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePolicyConfig error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
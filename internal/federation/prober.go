@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// probeHistorySize is the number of probe results kept per endpoint
+const probeHistorySize = 20
+
+// ProbeResult is the outcome of a single active health check
+type ProbeResult struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Healthy   bool      `json:"healthy"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// startProber starts the background health prober if health checking is enabled
+func (c *Client) startProber() {
+	hc := c.config.HealthCheck
+	if hc == nil || !hc.Enabled {
+		return
+	}
+
+	c.stopProbe = make(chan struct{})
+	c.probeDone = make(chan struct{})
+	method := hc.GetMethod()
+	log.Printf("[Federation] Health checking endpoint '%s' every %v using %s",
+		c.config.Name, hc.GetInterval(), method)
+
+	go func() {
+		defer close(c.probeDone)
+		ticker := time.NewTicker(hc.GetInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				method = c.probe(method, hc.GetTimeout())
+			case <-c.stopProbe:
+				return
+			}
+		}
+	}()
+}
+
+// stopProber stops the background health prober and waits for it to exit
+func (c *Client) stopProber() {
+	if c.stopProbe == nil {
+		return
+	}
+	close(c.stopProbe)
+	<-c.probeDone
+	c.stopProbe = nil
+}
+
+// probe runs a single health check against every replica and updates the
+// client state. The endpoint is healthy while at least one replica is.
+// Replicas are probed concurrently, each with its own timeout, so a replica
+// that hangs cannot fail the probes of the others.
+// It returns the method to use for the next probe; endpoints that do not
+// implement the gRPC health service fall back to a synthetic ping.
+func (c *Client) probe(method string, timeout time.Duration) string {
+	start := time.Now()
+	var err error
+	replicas := c.replicaSet()
+	if len(replicas) == 0 {
+		err = fmt.Errorf("no instances")
+	}
+
+	errs := make([]error, len(replicas))
+	fellBack := make([]bool, len(replicas))
+	var wg sync.WaitGroup
+	for i, r := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if method == HealthCheckGRPC {
+				errs[i] = c.probeGRPC(ctx, r)
+				if status.Code(errs[i]) != codes.Unimplemented {
+					return
+				}
+				fellBack[i] = true
+			}
+			errs[i] = c.probePing(ctx, r)
+		}()
+	}
+	wg.Wait()
+
+	healthy := 0
+	for i, r := range replicas {
+		if fellBack[i] && method == HealthCheckGRPC {
+			log.Printf("[Federation] Endpoint '%s' does not implement grpc.health.v1, falling back to ping", c.config.Name)
+			method = HealthCheckPing
+		}
+		r.setHealth(errs[i])
+		if errs[i] == nil {
+			healthy++
+		} else {
+			err = fmt.Errorf("%s: %w", r.address, errs[i])
+		}
+	}
+	if healthy > 0 {
+		err = nil
+	}
+
+	result := ProbeResult{
+		Time:      start,
+		Method:    method,
+		Healthy:   err == nil,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	c.recordProbe(result, err)
+	return method
+}
+
+// probeGRPC checks a replica using the standard gRPC health protocol
+func (c *Client) probeGRPC(ctx context.Context, r *replica) error {
+	resp, err := healthpb.NewHealthClient(r.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("health status %v", resp.GetStatus())
+	}
+	return nil
+}
+
+// probePing checks a replica with a synthetic GetMutations call
+func (c *Client) probePing(ctx context.Context, r *replica) error {
+	tmax := int32(c.config.GetTimeoutMs(c.defaults))
+	req := &pb.RTBRequest{
+		Id:   stringPtr(fmt.Sprintf("health-check-%d", time.Now().UnixNano())),
+		Tmax: &tmax,
+	}
+	_, err := r.rtbClient.GetMutations(ctx, req)
+	return err
+}
+
+// recordProbe appends a probe result to the history and updates health
+func (c *Client) recordProbe(result ProbeResult, err error) {
+	c.mu.Lock()
+	c.probes = append(c.probes, result)
+	if len(c.probes) > probeHistorySize {
+		c.probes = c.probes[len(c.probes)-probeHistorySize:]
+	}
+	wasHealthy := c.healthy
+	if err != nil && !wasHealthy {
+		c.lastError = err
+		c.lastCheck = result.Time
+	}
+	c.mu.Unlock()
+
+	if err == nil {
+		c.markHealthy()
+	} else if wasHealthy {
+		c.markUnhealthy(err)
+	}
+}
+
+// ProbeHistory returns the most recent health check results, oldest first
+func (c *Client) ProbeHistory() []ProbeResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	history := make([]ProbeResult, len(c.probes))
+	copy(history, c.probes)
+	return history
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RedactionConfig limits the request data sent to an endpoint to the fields
+// it needs. Fields not allowed are removed from the endpoint's copy of the
+// RTBRequest. The request envelope (lifecycle, id, tmax, originator and
+// applicable_intents) is always sent.
+type RedactionConfig struct {
+	// AllowedFields are RTBRequest field paths, such as "bid_request.imp" or
+	// "bid_request.site.domain". Allowing a message field sends all of it.
+	AllowedFields []string `json:"allowed_fields,omitempty" yaml:"allowed_fields,omitempty"`
+
+	// Presets adds the built-in field paths for each listed intent. If neither
+	// fields nor presets are given, the presets for the endpoint's applicable
+	// intents are used.
+	Presets []string `json:"presets,omitempty" yaml:"presets,omitempty"`
+}
+
+// envelopeFields are the RTBRequest fields every endpoint receives
+var envelopeFields = []string{"lifecycle", "id", "tmax", "originator", "applicable_intents"}
+
+// redactionPresets are the field paths each intent needs to produce its mutations
+var redactionPresets = map[string][]string{
+	"ACTIVATE_SEGMENTS": {
+		"bid_request.id", "bid_request.imp.id",
+		"bid_request.site.domain", "bid_request.site.page", "bid_request.site.cat",
+		"bid_request.app.bundle", "bid_request.app.cat",
+		"bid_request.user.data", "bid_request.user.eids", "bid_request.regs",
+	},
+	"ACTIVATE_DEALS": {
+		"bid_request.id", "bid_request.imp.id", "bid_request.imp.pmp",
+		"bid_request.imp.bidfloor", "bid_request.imp.bidfloorcur",
+		"bid_request.imp.banner", "bid_request.imp.video", "bid_request.imp.audio", "bid_request.imp.native",
+		"bid_request.site.domain", "bid_request.site.publisher",
+		"bid_request.app.bundle", "bid_request.app.publisher", "bid_request.regs",
+	},
+	"SUPPRESS_DEALS": {
+		"bid_request.id", "bid_request.imp.id", "bid_request.imp.pmp",
+		"bid_request.site.domain", "bid_request.app.bundle", "bid_request.regs",
+	},
+	"ADJUST_DEAL_FLOOR": {
+		"bid_request.id", "bid_request.imp.id", "bid_request.imp.pmp",
+		"bid_request.imp.bidfloor", "bid_request.imp.bidfloorcur",
+	},
+	"ADJUST_DEAL_MARGIN": {
+		"bid_request.id", "bid_request.imp.id", "bid_request.imp.pmp",
+		"bid_request.imp.bidfloor", "bid_request.imp.bidfloorcur",
+	},
+	"BID_SHADE": {
+		"bid_request.id", "bid_request.imp.id",
+		"bid_request.imp.bidfloor", "bid_request.imp.bidfloorcur",
+		"bid_request.site.domain", "bid_request.app.bundle", "bid_response",
+	},
+	"ADD_METRICS": {
+		"bid_request.id", "bid_request.imp.id", "bid_request.imp.metric",
+		"bid_request.imp.banner", "bid_request.imp.video",
+		"bid_request.site.domain", "bid_request.app.bundle",
+	},
+	"ADD_CIDS": {
+		"bid_request.id",
+		"bid_request.site.domain", "bid_request.site.page", "bid_request.site.content",
+		"bid_request.app.bundle", "bid_request.app.content",
+	},
+}
+
+// fieldTree is a compiled set of allowed field paths. A nil subtree allows
+// the whole field.
+type fieldTree map[protoreflect.Name]fieldTree
+
+// validate checks that presets name intents and field paths exist
+func (r *RedactionConfig) validate() error {
+	for _, preset := range r.Presets {
+		if !isValidIntent(preset) {
+			return fmt.Errorf("redaction: invalid preset '%s'", preset)
+		}
+	}
+	_, err := r.compile(nil)
+	return err
+}
+
+// Fields returns the allowed field paths, resolving presets against the
+// endpoint's applicable intents
+func (r *RedactionConfig) Fields(intents []string) []string {
+	presets := r.Presets
+	if len(r.AllowedFields) == 0 && len(presets) == 0 {
+		presets = intents
+		if len(presets) == 0 {
+			presets = ValidIntents
+		}
+	}
+
+	seen := make(map[string]bool)
+	var fields []string
+	add := func(paths []string) {
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				fields = append(fields, path)
+			}
+		}
+	}
+	add(envelopeFields)
+	add(r.AllowedFields)
+	for _, preset := range presets {
+		add(redactionPresets[strings.ToUpper(preset)])
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// AllowedFields returns the request field paths sent to the endpoint, or nil
+// if the endpoint receives the full request
+func (e *EndpointConfig) AllowedFields() []string {
+	if e.Redaction == nil {
+		return nil
+	}
+	return e.Redaction.Fields(e.ApplicableIntents)
+}
+
+// compile resolves the allowed field paths against the RTBRequest schema
+func (r *RedactionConfig) compile(intents []string) (fieldTree, error) {
+	root := (&pb.RTBRequest{}).ProtoReflect().Descriptor()
+	tree := fieldTree{}
+	for _, path := range r.Fields(intents) {
+		if err := tree.add(root, path); err != nil {
+			return nil, fmt.Errorf("redaction: %w", err)
+		}
+	}
+	return tree, nil
+}
+
+// add inserts a dot-separated field path, checking each step against the message descriptor
+func (t fieldTree) add(desc protoreflect.MessageDescriptor, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		if desc == nil {
+			return fmt.Errorf("field path '%s': '%s' is not a message", path, names[i-1])
+		}
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("field path '%s': unknown field '%s'", path, name)
+		}
+		desc = nil
+		if fd.Message() != nil && !fd.IsMap() {
+			desc = fd.Message()
+		}
+	}
+
+	node := t
+	for i, name := range names {
+		sub, exists := node[protoreflect.Name(name)]
+		if exists && sub == nil {
+			// The whole field is already allowed
+			return nil
+		}
+		if i == len(names)-1 {
+			node[protoreflect.Name(name)] = nil
+			return nil
+		}
+		if !exists {
+			sub = fieldTree{}
+			node[protoreflect.Name(name)] = sub
+		}
+		node = sub
+	}
+	return nil
+}
+
+// prune clears every field of m that the tree does not allow
+func (t fieldTree) prune(m protoreflect.Message) {
+	var cleared []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := t[fd.Name()]
+		switch {
+		case fd.IsExtension() || !ok:
+			cleared = append(cleared, fd)
+		case sub == nil:
+			// Allowed in full
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				sub.prune(list.Get(i).Message())
+			}
+		default:
+			sub.prune(v.Message())
+		}
+		return true
+	})
+	for _, fd := range cleared {
+		m.Clear(fd)
+	}
+	m.SetUnknown(nil)
+}
+
+// redact removes the fields the endpoint is not allowed to see from its copy
+// of the request
+func (c *Client) redact(req *pb.RTBRequest) {
+	if c.redaction == nil {
+		return
+	}
+	c.redaction.prune(req.ProtoReflect())
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"time"
+)
+
+// Reload replaces the federation configuration without interrupting traffic.
+// Endpoints whose configuration is unchanged keep their client; new and
+// changed endpoints get a new client, and removed or replaced clients are
+// closed once in-flight calls have had time to finish. If the new
+// configuration is invalid the current one is kept and an error is returned.
+func (m *Manager) Reload(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	// Manifests are fetched and instances discovered without holding the lock,
+	// so requests keep using the current configuration in the meantime
+	old := m.Config()
+	update, err := m.pool.prepare(config)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	retired := m.pool.apply(update)
+	m.config = config
+	m.mu.Unlock()
+
+	added, removed, changed := diffEndpoints(old, config)
+	log.Printf("[Federation] Config reloaded: %d endpoints added %v, %d removed %v, %d changed %v",
+		len(added), added, len(removed), removed, len(changed), changed)
+
+	// Give in-flight calls on retired clients their full timeout before closing
+	if len(retired) > 0 {
+		grace := time.Duration(maxTimeoutMs(old)) * time.Millisecond
+		time.AfterFunc(grace, func() {
+			for _, c := range retired {
+				if err := c.Close(); err != nil {
+					log.Printf("[Federation] Error closing retired client '%s': %v", c.config.Name, err)
+				}
+			}
+		})
+	}
+	return nil
+}
+
+// ReloadFromFile loads a configuration file and reloads the manager with it
+func (m *Manager) ReloadFromFile(path string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return m.Reload(config)
+}
+
+// Watch reloads the configuration whenever the file changes, until ctx is done.
+// Errors are logged and the current configuration is kept.
+func (m *Manager) Watch(ctx context.Context, path string, interval time.Duration) {
+	log.Printf("[Federation] Watching %s for changes every %v", path, interval)
+	watchFile(ctx, path, interval, func() {
+		if err := m.ReloadFromFile(path); err != nil {
+			log.Printf("[Federation] Reload of %s failed, keeping current config: %v", path, err)
+		}
+	})
+}
+
+// poolUpdate is the client set for a new configuration, built by prepare and
+// installed by apply
+type poolUpdate struct {
+	config     *Config
+	clients    map[string]*Client
+	admissions map[string]*AdmissionResult
+}
+
+// prepare builds the client set for a new configuration without changing the
+// pool. Callers must not update the pool between prepare and apply.
+func (p *ClientPool) prepare(config *Config) (*poolUpdate, error) {
+	p.mu.RLock()
+	current := p.clients
+	defaultsChanged := !reflect.DeepEqual(p.config.Defaults, config.Defaults)
+	admissionChanged := !reflect.DeepEqual(p.config.Admission, config.Admission)
+	p.mu.RUnlock()
+
+	// Unchanged, admitted endpoints keep their client; the rest, including
+	// refused and quarantined endpoints, are checked again
+	next := make(map[string]*Client)
+	admissions := make(map[string]*AdmissionResult)
+	var created []*Client
+	for _, ep := range config.GetEnabledEndpoints() {
+		if c, ok := current[ep.Name]; ok && !defaultsChanged && !admissionChanged &&
+			c.admission.Admitted() && reflect.DeepEqual(c.config, ep) {
+			next[ep.Name] = c
+			if c.admission != nil {
+				admissions[ep.Name] = c.admission
+			}
+			continue
+		}
+		admission := admit(config.Admission, ep)
+		if admission != nil {
+			admissions[ep.Name] = admission
+			if admission.Status == AdmissionRefused {
+				continue
+			}
+		}
+		c, err := NewClient(ep, config.Defaults)
+		if err != nil {
+			for _, c := range created {
+				c.Close()
+			}
+			return nil, fmt.Errorf("failed to create client for endpoint '%s': %w", ep.Name, err)
+		}
+		c.admission = admission
+		created = append(created, c)
+		next[ep.Name] = c
+	}
+
+	return &poolUpdate{config: config, clients: next, admissions: admissions}, nil
+}
+
+// apply installs a prepared client set and returns the clients that are no
+// longer in use
+func (p *ClientPool) apply(update *poolUpdate) []*Client {
+	p.mu.Lock()
+	current := p.clients
+	p.clients = update.clients
+	p.admissions = update.admissions
+	p.config = update.config
+	p.mu.Unlock()
+
+	// Reused clients start over with intent enforcement, so an endpoint
+	// disabled until the next reload is enabled again
+	var retired []*Client
+	for name, c := range current {
+		if update.clients[name] != c {
+			retired = append(retired, c)
+			continue
+		}
+		c.guard.reset()
+	}
+	return retired
+}
+
+// diffEndpoints returns the names of endpoints added, removed and changed between two configs
+func diffEndpoints(before, after *Config) (added, removed, changed []string) {
+	for _, ep := range after.Endpoints {
+		prev := before.GetEndpointByName(ep.Name)
+		switch {
+		case prev == nil:
+			added = append(added, ep.Name)
+		case !reflect.DeepEqual(*prev, ep):
+			changed = append(changed, ep.Name)
+		}
+	}
+	for _, ep := range before.Endpoints {
+		if after.GetEndpointByName(ep.Name) == nil {
+			removed = append(removed, ep.Name)
+		}
+	}
+	return added, removed, changed
+}
+
+// maxTimeoutMs returns the largest endpoint timeout in a configuration
+func maxTimeoutMs(config *Config) int {
+	longest := 0
+	for _, ep := range config.Endpoints {
+		if t := ep.GetTimeoutMs(config.Defaults); t > longest {
+			longest = t
+		}
+	}
+	return longest
+}
+
+// fileStamp identifies a version of a file by modification time and size
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+// statFile returns the current stamp of a file, or the zero stamp if it cannot be read
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{mod: info.ModTime(), size: info.Size()}
+}
+
+// watchFile calls onChange whenever the file's modification time or size
+// changes, polling at the given interval until ctx is done. Polling works
+// with editors that replace files and with Kubernetes ConfigMap symlink swaps.
+func watchFile(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	watchFileFrom(ctx, path, interval, statFile(path), onChange)
+}
+
+// watchFileFrom is watchFile with an explicit starting stamp, so callers that
+// read the file before watching do not miss changes made in between
+func watchFileFrom(ctx context.Context, path string, interval time.Duration, last fileStamp, onChange func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			current := fileStamp{mod: info.ModTime(), size: info.Size()}
+			if current == last {
+				continue
+			}
+			last = current
+			onChange()
+		}
+	}
+}
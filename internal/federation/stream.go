@@ -0,0 +1,342 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamingConfig sends an endpoint's requests over one StreamMutations
+// stream per instance instead of a GetMutations call each. Instances that do
+// not implement StreamMutations are called with GetMutations.
+type StreamingConfig struct {
+	// Enabled determines if requests are streamed
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// MaxInFlight is the number of requests awaiting a response on a stream
+	// (default: 100). Further requests wait for a free slot within their deadline.
+	MaxInFlight int `json:"max_in_flight,omitempty" yaml:"max_in_flight,omitempty"`
+}
+
+// GetMaxInFlight returns the number of requests that may await a response on a stream
+func (s *StreamingConfig) GetMaxInFlight() int {
+	if s.MaxInFlight <= 0 {
+		return 100
+	}
+	return s.MaxInFlight
+}
+
+// GetStreaming returns the streaming configuration, or nil if streaming is disabled
+func (e *EndpointConfig) GetStreaming(defaults *EndpointDefaults) *StreamingConfig {
+	streaming := e.Streaming
+	if streaming == nil && defaults != nil {
+		streaming = defaults.Streaming
+	}
+	if streaming == nil || !streaming.Enabled {
+		return nil
+	}
+	return streaming
+}
+
+// mutationStream multiplexes the requests to one instance over a
+// StreamMutations stream. The stream is opened on first use and again after
+// it fails.
+type mutationStream struct {
+	address     string
+	client      pb.RTBExtensionPointClient
+	slots       chan struct{}
+	unsupported atomic.Bool
+
+	mu      sync.Mutex
+	current *streamConn
+}
+
+// streamConn is one open stream and the requests awaiting a response on it
+type streamConn struct {
+	stream pb.RTBExtensionPoint_StreamMutationsClient
+	cancel context.CancelFunc
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string][]chan streamResult
+	err     error
+}
+
+// streamResult is the response to a streamed request, or the stream's error
+type streamResult struct {
+	resp *pb.RTBResponse
+	err  error
+}
+
+// newMutationStream creates a stream to the instance; nothing is sent until first use
+func newMutationStream(address string, client pb.RTBExtensionPointClient, config *StreamingConfig) *mutationStream {
+	return &mutationStream{
+		address: address,
+		client:  client,
+		slots:   make(chan struct{}, config.GetMaxInFlight()),
+	}
+}
+
+// getMutations calls the instance over its stream if streaming is enabled and
+// supported, and with GetMutations otherwise. Requests without an id cannot be
+// correlated on a stream and are always sent with GetMutations.
+func (r *replica) getMutations(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	if r.stream != nil && !r.stream.unsupported.Load() && req.GetId() != "" {
+		resp, err := r.stream.call(ctx, req)
+		if status.Code(err) != codes.Unimplemented {
+			return resp, err
+		}
+	}
+	return r.rtbClient.GetMutations(ctx, req)
+}
+
+// call sends a request on the stream in a client span of the caller's trace.
+// The stream's gRPC metadata belongs to whichever request opened it, so the
+// span's trace context is carried in the request's ext instead.
+func (s *mutationStream) call(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "mutationStream.call",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(tracing.RequestAttributes(req),
+			attribute.String("artf.instance", s.address))...))
+	defer span.End()
+
+	resp, err := s.roundTrip(ctx, tracing.InjectRequest(ctx, req))
+	tracing.RecordError(span, err)
+	return resp, err
+}
+
+// roundTrip sends a request on the stream and waits for the response with the
+// same id. The wait, including for a free slot, is bounded by ctx and the
+// request's tmax.
+func (s *mutationStream) roundTrip(ctx context.Context, req *pb.RTBRequest) (*pb.RTBResponse, error) {
+	if tmax := req.GetTmax(); tmax > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(tmax)*time.Millisecond)
+		defer cancel()
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	// The slot is held until the request has been sent and its caller is done,
+	// so a stalled stream cannot accumulate more than the in-flight limit
+	holders := int32(2)
+	release := func() {
+		if atomic.AddInt32(&holders, -1) == 0 {
+			<-s.slots
+		}
+	}
+	defer release()
+
+	conn, err := s.open()
+	if err != nil {
+		release()
+		return nil, err
+	}
+	result := conn.register(req.GetId())
+
+	// Send blocks while the agent is not reading, so it must not hold up the caller
+	sent := make(chan error, 1)
+	go func() {
+		defer release()
+		sent <- conn.send(req)
+	}()
+
+	for {
+		select {
+		case err := <-sent:
+			// io.EOF means the stream has ended; its status is reported by receive
+			if err != nil && err != io.EOF {
+				s.fail(conn, err)
+			}
+			sent = nil
+		case r := <-result:
+			return r.resp, r.err
+		case <-ctx.Done():
+			conn.unregister(req.GetId(), result)
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// open returns the current stream, opening one if there is none
+func (s *mutationStream) open() (*streamConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil {
+		return s.current, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := s.client.StreamMutations(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	conn := &streamConn{
+		stream:  stream,
+		cancel:  cancel,
+		pending: make(map[string][]chan streamResult),
+	}
+	s.current = conn
+	go s.receive(conn)
+	return conn, nil
+}
+
+// receive delivers responses to the requests awaiting them until the stream fails
+func (s *mutationStream) receive(conn *streamConn) {
+	for {
+		resp, err := conn.stream.Recv()
+		if err == io.EOF {
+			err = status.Error(codes.Unavailable, "stream closed by agent")
+		}
+		if err != nil {
+			s.fail(conn, err)
+			return
+		}
+		if !conn.deliver(resp) {
+			log.Printf("[Federation] Stream to %s dropped response to request %s: no request is waiting",
+				s.address, resp.GetId())
+		}
+	}
+}
+
+// fail closes a stream, failing every request awaiting a response on it.
+// The next call opens a new stream.
+func (s *mutationStream) fail(conn *streamConn, err error) {
+	s.mu.Lock()
+	if s.current == conn {
+		s.current = nil
+	}
+	s.mu.Unlock()
+	conn.cancel()
+
+	if !conn.close(err) {
+		return
+	}
+	switch status.Code(err) {
+	case codes.Canceled:
+	case codes.Unimplemented:
+		if !s.unsupported.Swap(true) {
+			log.Printf("[Federation] %s does not implement StreamMutations, using GetMutations", s.address)
+		}
+	default:
+		log.Printf("[Federation] Stream to %s failed: %v", s.address, err)
+	}
+}
+
+// close cancels the current stream, if any
+func (s *mutationStream) close() {
+	s.mu.Lock()
+	conn := s.current
+	s.current = nil
+	s.mu.Unlock()
+	if conn != nil {
+		conn.cancel()
+	}
+}
+
+// register adds a request awaiting a response. If the stream has already
+// failed the returned channel holds its error.
+func (c *streamConn) register(id string) chan streamResult {
+	result := make(chan streamResult, 1)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		result <- streamResult{err: c.err}
+		return result
+	}
+	c.pending[id] = append(c.pending[id], result)
+	return result
+}
+
+// unregister removes a request that is no longer awaiting a response
+func (c *streamConn) unregister(id string, result chan streamResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waiting := c.pending[id]
+	for i, ch := range waiting {
+		if ch == result {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(c.pending, id)
+	} else {
+		c.pending[id] = waiting
+	}
+}
+
+// deliver passes a response to the oldest request with its id. It returns
+// false if no request is waiting, such as when the response is too late.
+func (c *streamConn) deliver(resp *pb.RTBResponse) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waiting := c.pending[resp.GetId()]
+	if len(waiting) == 0 {
+		return false
+	}
+	waiting[0] <- streamResult{resp: resp}
+	if len(waiting) == 1 {
+		delete(c.pending, resp.GetId())
+	} else {
+		c.pending[resp.GetId()] = waiting[1:]
+	}
+	return true
+}
+
+// close fails every request awaiting a response. It returns false if the
+// stream had already failed.
+func (c *streamConn) close(err error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false
+	}
+	c.err = err
+	for _, waiting := range c.pending {
+		for _, result := range waiting {
+			result <- streamResult{err: err}
+		}
+	}
+	c.pending = nil
+	return true
+}
+
+// send writes a request to the stream; Send is not safe for concurrent use
+func (c *streamConn) send(req *pb.RTBRequest) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.stream.Send(req)
+}
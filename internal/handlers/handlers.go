@@ -21,19 +21,24 @@ package handlers
 import (
 	"context"
 	"log"
+	"sync"
 
 	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
 	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
 )
 
 // MutationHandlers contains all registered mutation handlers
 type MutationHandlers struct {
-	// Add any configuration or dependencies here
+	mu       sync.RWMutex
+	handlers []Handler
 }
 
-// NewMutationHandlers creates a new handlers instance
+// NewMutationHandlers creates a new handlers instance with the built-in handlers registered
 func NewMutationHandlers() *MutationHandlers {
-	return &MutationHandlers{}
+	h := &MutationHandlers{}
+	h.registerBuiltins()
+	return h
 }
 
 // IsIntentApplicable checks if an intent is in the applicable intents list.
@@ -74,7 +79,7 @@ func (h *MutationHandlers) ProcessSegments(ctx context.Context, req *openrtb.Bid
 			mutation := &pb.Mutation{
 				Intent: pb.Intent_ACTIVATE_SEGMENTS.Enum(),
 				Op:     pb.Operation_OPERATION_ADD.Enum(),
-				Path:   stringPtr("/user/data/segment"),
+				Path:   stringPtr(patch.UserSegmentsPath().String()),
 				Value: &pb.Mutation_Ids{
 					Ids: &pb.IDsPayload{
 						Id: segments,
@@ -112,7 +117,7 @@ func (h *MutationHandlers) ProcessDeals(ctx context.Context, req *openrtb.BidReq
 				mutation := &pb.Mutation{
 					Intent: pb.Intent_ACTIVATE_DEALS.Enum(),
 					Op:     pb.Operation_OPERATION_ADD.Enum(),
-					Path:   stringPtr("/imp/" + impID),
+					Path:   stringPtr(patch.ImpPath(impID).String()),
 					Value: &pb.Mutation_Ids{
 						Ids: &pb.IDsPayload{
 							Id: dealsToActivate,
@@ -130,7 +135,7 @@ func (h *MutationHandlers) ProcessDeals(ctx context.Context, req *openrtb.BidReq
 				mutation := &pb.Mutation{
 					Intent: pb.Intent_ADJUST_DEAL_FLOOR.Enum(),
 					Op:     pb.Operation_OPERATION_REPLACE.Enum(),
-					Path:   stringPtr("/imp/" + impID + "/pmp/deals"),
+					Path:   stringPtr(patch.ImpDealsPath(impID).String()),
 					Value: &pb.Mutation_AdjustDeal{
 						AdjustDeal: floorAdjustment,
 					},
@@ -166,7 +171,7 @@ func (h *MutationHandlers) ProcessBidShading(ctx context.Context, req *openrtb.B
 				mutation := &pb.Mutation{
 					Intent: pb.Intent_BID_SHADE.Enum(),
 					Op:     pb.Operation_OPERATION_REPLACE.Enum(),
-					Path:   stringPtr("/seatbid/" + seatbid.GetSeat() + "/bid/" + bid.GetId()),
+					Path:   stringPtr(patch.BidPath(seatbid.GetSeat(), bid.GetId()).String()),
 					Value: &pb.Mutation_AdjustBid{
 						AdjustBid: &pb.AdjustBidPayload{
 							Price: shadedPrice,
@@ -183,15 +188,51 @@ func (h *MutationHandlers) ProcessBidShading(ctx context.Context, req *openrtb.B
 	return mutations, nil
 }
 
-// ProcessContentData analyzes bid request and returns content ID mutations.
+// ProcessContentData analyzes the site or app content and returns content ID mutations.
 // Respects applicableIntents filtering for ADD_CIDS intent.
-// NOTE: ADD_CIDS intent requires protobuf regeneration to be fully supported.
-// This is a placeholder that returns nil until protos are regenerated.
 func (h *MutationHandlers) ProcessContentData(ctx context.Context, req *openrtb.BidRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
-	// ADD_CIDS (Intent 8) is defined in proto but not yet in generated Go code.
-	// After running `make bindings`, this can be fully implemented.
-	// For now, return nil to allow compilation.
-	return nil, nil
+	if req == nil {
+		return nil, nil
+	}
+
+	// Check if ADD_CIDS intent is applicable
+	if !IsIntentApplicable(pb.Intent_ADD_CIDS, applicableIntents) {
+		return nil, nil
+	}
+
+	// Content lives on either the site or the app object
+	var content *openrtb.BidRequest_Content
+	var path patch.Path
+	if site := req.GetSite(); site != nil && site.GetContent() != nil {
+		content = site.GetContent()
+		path = patch.SiteContentDataPath()
+	} else if app := req.GetApp(); app != nil && app.GetContent() != nil {
+		content = app.GetContent()
+		path = patch.AppContentDataPath()
+	}
+	if content == nil {
+		return nil, nil
+	}
+
+	var mutations []*pb.Mutation
+
+	data := determineContentData(content)
+	if data != nil {
+		mutation := &pb.Mutation{
+			Intent: pb.Intent_ADD_CIDS.Enum(),
+			Op:     pb.Operation_OPERATION_ADD.Enum(),
+			Path:   stringPtr(path.String()),
+			Value: &pb.Mutation_ContentData{
+				ContentData: &pb.DataPayload{
+					Data: []*openrtb.BidRequest_Data{data},
+				},
+			},
+		}
+		mutations = append(mutations, mutation)
+		log.Printf("Adding %d content IDs at %s", len(data.GetSegment()), path)
+	}
+
+	return mutations, nil
 }
 
 // determineUserSegments analyzes user data and returns applicable segment IDs
@@ -247,6 +288,44 @@ func determineDealActivations(imp *openrtb.BidRequest_Imp) []string {
 	return deals
 }
 
+// determineContentData returns extended content IDs for the given content object
+func determineContentData(content *openrtb.BidRequest_Content) *openrtb.BidRequest_Data {
+	var segments []*openrtb.BidRequest_Data_Segment
+
+	// Example logic - in production this would call a contextual classification service
+	if id := content.GetId(); id != "" {
+		segments = append(segments, &openrtb.BidRequest_Data_Segment{
+			Id:   stringPtr("cid-" + id),
+			Name: stringPtr("Content ID"),
+		})
+	}
+
+	// Map content categories to extended content IDs
+	for _, cat := range content.GetCat() {
+		segments = append(segments, &openrtb.BidRequest_Data_Segment{
+			Id:   stringPtr("cat-" + cat),
+			Name: stringPtr("Content Category"),
+		})
+	}
+
+	if genre := content.GetGenre(); genre != "" {
+		segments = append(segments, &openrtb.BidRequest_Data_Segment{
+			Id:   stringPtr("genre-" + genre),
+			Name: stringPtr("Content Genre"),
+		})
+	}
+
+	if len(segments) == 0 {
+		return nil
+	}
+
+	return &openrtb.BidRequest_Data{
+		Id:      stringPtr("artf-cids"),
+		Name:    stringPtr("ARTF Content IDs"),
+		Segment: segments,
+	}
+}
+
 // calculateDealFloorAdjustment calculates floor adjustments for deals
 func calculateDealFloorAdjustment(imp *openrtb.BidRequest_Imp) *pb.AdjustDealPayload {
 	pmp := imp.GetPmp()
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+)
+
+// Handler is a pluggable unit of business logic that proposes mutations.
+// Handlers are registered on MutationHandlers and selected per request
+// based on the request lifecycle and applicable_intents.
+type Handler interface {
+	// Name returns a unique name for this handler
+	Name() string
+
+	// Intents returns the intents this handler may emit
+	Intents() []pb.Intent
+
+	// Lifecycles returns the lifecycle stages this handler runs at.
+	// If empty, the handler runs at every lifecycle stage.
+	Lifecycles() []pb.Lifecycle
+
+	// Process analyzes the request and returns proposed mutations.
+	// Only mutations for intents in applicableIntents should be returned;
+	// if applicableIntents is empty, all intents are applicable.
+	Process(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error)
+}
+
+// Register adds a handler to the registry.
+// Returns an error if a handler with the same name is already registered.
+func (h *MutationHandlers) Register(handler Handler) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, existing := range h.handlers {
+		if existing.Name() == handler.Name() {
+			return fmt.Errorf("handler '%s' is already registered", handler.Name())
+		}
+	}
+	h.handlers = append(h.handlers, handler)
+	return nil
+}
+
+// Unregister removes a handler by name. Returns false if no such handler exists.
+func (h *MutationHandlers) Unregister(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, existing := range h.handlers {
+		if existing.Name() == name {
+			h.handlers = append(h.handlers[:i], h.handlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Handlers returns all registered handlers in registration order
+func (h *MutationHandlers) Handlers() []Handler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	handlers := make([]Handler, len(h.handlers))
+	copy(handlers, h.handlers)
+	return handlers
+}
+
+// Intents returns the distinct intents the registered handlers may emit, in
+// registration order
+func (h *MutationHandlers) Intents() []pb.Intent {
+	seen := make(map[pb.Intent]bool)
+	var intents []pb.Intent
+	for _, handler := range h.Handlers() {
+		for _, intent := range handler.Intents() {
+			if !seen[intent] {
+				seen[intent] = true
+				intents = append(intents, intent)
+			}
+		}
+	}
+	return intents
+}
+
+// Select returns the registered handlers that should run for the given
+// lifecycle stage and applicable intents. An unspecified lifecycle matches
+// every handler.
+func (h *MutationHandlers) Select(lifecycle pb.Lifecycle, applicableIntents []pb.Intent) []Handler {
+	var selected []Handler
+	for _, handler := range h.Handlers() {
+		if !supportsLifecycle(handler, lifecycle) {
+			continue
+		}
+		if !supportsAnyIntent(handler, applicableIntents) {
+			continue
+		}
+		selected = append(selected, handler)
+	}
+	return selected
+}
+
+// supportsLifecycle checks if a handler runs at the given lifecycle stage
+func supportsLifecycle(handler Handler, lifecycle pb.Lifecycle) bool {
+	lifecycles := handler.Lifecycles()
+	if len(lifecycles) == 0 || lifecycle == pb.Lifecycle_LIFECYCLE_UNSPECIFIED {
+		return true
+	}
+	for _, lc := range lifecycles {
+		if lc == lifecycle {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsAnyIntent checks if a handler emits at least one applicable intent
+func supportsAnyIntent(handler Handler, applicableIntents []pb.Intent) bool {
+	for _, intent := range handler.Intents() {
+		if IsIntentApplicable(intent, applicableIntents) {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinHandler adapts one of the built-in MutationHandlers methods to the Handler interface
+type builtinHandler struct {
+	name       string
+	intents    []pb.Intent
+	lifecycles []pb.Lifecycle
+	process    func(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error)
+}
+
+func (b *builtinHandler) Name() string               { return b.name }
+func (b *builtinHandler) Intents() []pb.Intent       { return b.intents }
+func (b *builtinHandler) Lifecycles() []pb.Lifecycle { return b.lifecycles }
+
+func (b *builtinHandler) Process(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
+	return b.process(ctx, req, applicableIntents)
+}
+
+// registerBuiltins registers the reference segment, deal, bid shading and content handlers
+func (h *MutationHandlers) registerBuiltins() {
+	builtins := []*builtinHandler{
+		{
+			name:       "segments",
+			intents:    []pb.Intent{pb.Intent_ACTIVATE_SEGMENTS},
+			lifecycles: []pb.Lifecycle{pb.Lifecycle_LIFECYCLE_PUBLISHER_BID_REQUEST},
+			process: func(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
+				return h.ProcessSegments(ctx, req.GetBidRequest(), applicableIntents)
+			},
+		},
+		{
+			name:       "deals",
+			intents:    []pb.Intent{pb.Intent_ACTIVATE_DEALS, pb.Intent_ADJUST_DEAL_FLOOR},
+			lifecycles: []pb.Lifecycle{pb.Lifecycle_LIFECYCLE_PUBLISHER_BID_REQUEST},
+			process: func(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
+				return h.ProcessDeals(ctx, req.GetBidRequest(), applicableIntents)
+			},
+		},
+		{
+			name:       "bid-shading",
+			intents:    []pb.Intent{pb.Intent_BID_SHADE},
+			lifecycles: []pb.Lifecycle{pb.Lifecycle_LIFECYCLE_DSP_BID_RESPONSE},
+			process: func(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
+				return h.ProcessBidShading(ctx, req.GetBidRequest(), req.GetBidResponse(), applicableIntents)
+			},
+		},
+		{
+			name:       "content-data",
+			intents:    []pb.Intent{pb.Intent_ADD_CIDS},
+			lifecycles: []pb.Lifecycle{pb.Lifecycle_LIFECYCLE_PUBLISHER_BID_REQUEST},
+			process: func(ctx context.Context, req *pb.RTBRequest, applicableIntents []pb.Intent) ([]*pb.Mutation, error) {
+				return h.ProcessContentData(ctx, req.GetBidRequest(), applicableIntents)
+			},
+		},
+	}
+
+	for _, b := range builtins {
+		h.handlers = append(h.handlers, b)
+	}
+}
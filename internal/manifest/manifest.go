@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package manifest loads and validates the ARTF agent manifest, the JSON
+// document published as the "agent-manifest" container label that declares
+// an agent's owner, intents, resources and dependencies
+package manifest
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// LabelKey is the container image label carrying the manifest
+const LabelKey = "agent-manifest"
+
+//go:embed schema.json
+var schemaJSON string
+
+//go:embed agent-manifest.json
+var defaultJSON []byte
+
+// schema is the compiled manifest JSON Schema
+var schema = compileSchema()
+
+// compileSchema compiles the embedded JSON Schema
+func compileSchema() *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	if err := compiler.AddResource("agent-manifest.schema.json", strings.NewReader(schemaJSON)); err != nil {
+		panic(fmt.Sprintf("manifest: invalid embedded schema: %v", err))
+	}
+	return compiler.MustCompile("agent-manifest.schema.json")
+}
+
+// Schema returns the JSON Schema that manifests are validated against
+func Schema() []byte {
+	return []byte(schemaJSON)
+}
+
+// Manifest is the agent manifest defined by the ARTF specification
+type Manifest struct {
+	Name         string                `json:"name"`
+	Version      string                `json:"version"`
+	Description  string                `json:"description,omitempty"`
+	Vendor       string                `json:"vendor"`
+	Owner        string                `json:"owner"`
+	Image        *Image                `json:"image,omitempty"`
+	Resources    *Resources            `json:"resources,omitempty"`
+	Intents      []Intent              `json:"intents"`
+	Dependencies map[string]Dependency `json:"dependencies,omitempty"`
+	Health       *Health               `json:"health,omitempty"`
+	Security     *Security             `json:"security,omitempty"`
+	Maintainers  []Maintainer          `json:"maintainers,omitempty"`
+}
+
+// Image identifies the container image the manifest describes
+type Image struct {
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+}
+
+// Resources are the minimum CPU and memory the agent requires, in
+// Kubernetes quantity notation such as "500m" and "256Mi"
+type Resources struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// Intent is an intent the agent may emit. In JSON it is either the intent
+// name or an object with a name and description.
+type Intent struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string and object forms of an intent
+func (i *Intent) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		*i = Intent{}
+		return json.Unmarshal(data, &i.Name)
+	}
+	type plain Intent
+	return json.Unmarshal(data, (*plain)(i))
+}
+
+// MarshalJSON writes intents without a description in the string form
+func (i Intent) MarshalJSON() ([]byte, error) {
+	if i.Description == "" {
+		return json.Marshal(i.Name)
+	}
+	type plain Intent
+	return json.Marshal(plain(i))
+}
+
+// Dependency is another service the agent expects to reach
+type Dependency struct {
+	Service     string `json:"service,omitempty"`
+	Host        string `json:"host,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	EnvVariable string `json:"ENV_VARIABLE,omitempty"`
+}
+
+// Health declares the agent's HTTP probes
+type Health struct {
+	LivenessProbe  *Probe `json:"livenessProbe,omitempty"`
+	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
+}
+
+// Probe is a Kubernetes-style HTTP probe
+type Probe struct {
+	HTTPGet             HTTPGet `json:"httpGet"`
+	InitialDelaySeconds int     `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int     `json:"periodSeconds,omitempty"`
+}
+
+// HTTPGet is the path and port a probe requests
+type HTTPGet struct {
+	Path string `json:"path"`
+	Port int    `json:"port"`
+}
+
+// Security declares the container security requirements
+type Security struct {
+	RunAsNonRoot     bool             `json:"runAsNonRoot,omitempty"`
+	DropCapabilities []string         `json:"dropCapabilities,omitempty"`
+	NetworkPolicies  *NetworkPolicies `json:"networkPolicies,omitempty"`
+}
+
+// NetworkPolicies lists the services allowed to reach, and be reached by, the agent
+type NetworkPolicies struct {
+	Ingress []string `json:"ingress,omitempty"`
+	Egress  []string `json:"egress,omitempty"`
+}
+
+// Maintainer is a contact for the agent
+type Maintainer struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// Validate checks a manifest document against the JSON Schema
+func Validate(data []byte) error {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	return nil
+}
+
+// Parse validates and decodes a manifest document
+func Parse(data []byte) (*Manifest, error) {
+	if err := Validate(data); err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Load reads and validates a manifest file
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+// Default returns the manifest of the reference agent embedded in the binary
+func Default() *Manifest {
+	m, err := Parse(defaultJSON)
+	if err != nil {
+		panic(fmt.Sprintf("manifest: invalid embedded manifest: %v", err))
+	}
+	return m
+}
+
+// IntentNames returns the names of the declared intents
+func (m *Manifest) IntentNames() []string {
+	names := make([]string, len(m.Intents))
+	for i, intent := range m.Intents {
+		names[i] = intent.Name
+	}
+	return names
+}
+
+// IntentMismatch describes how the declared intents differ from the intents
+// an agent's handlers emit
+type IntentMismatch struct {
+	// Undeclared intents are emitted by a handler but not declared
+	Undeclared []string `json:"undeclared,omitempty"`
+
+	// Unused intents are declared but no handler emits them
+	Unused []string `json:"unused,omitempty"`
+}
+
+// CompareIntents checks the declared intents against the emitted ones.
+// Intent names are compared case-insensitively.
+func (m *Manifest) CompareIntents(emitted []string) IntentMismatch {
+	declared := make(map[string]bool, len(m.Intents))
+	for _, name := range m.IntentNames() {
+		declared[strings.ToUpper(name)] = true
+	}
+	produced := make(map[string]bool, len(emitted))
+	var mismatch IntentMismatch
+	for _, name := range emitted {
+		name = strings.ToUpper(name)
+		produced[name] = true
+		if !declared[name] {
+			mismatch.Undeclared = append(mismatch.Undeclared, name)
+		}
+	}
+	for name := range declared {
+		if !produced[name] {
+			mismatch.Unused = append(mismatch.Unused, name)
+		}
+	}
+	sort.Strings(mismatch.Undeclared)
+	sort.Strings(mismatch.Unused)
+	return mismatch
+}
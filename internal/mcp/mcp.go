@@ -30,10 +30,14 @@ import (
 
 	"github.com/iabtechlab/agentic-rtb-framework/internal/agent"
 	"github.com/iabtechlab/agentic-rtb-framework/internal/federation"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/manifest"
+	"github.com/iabtechlab/agentic-rtb-framework/internal/tracing"
 	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
 	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -52,6 +56,26 @@ func (a *Agent) SetFederationManager(fm *federation.Manager) {
 	a.federationManager = fm
 }
 
+// ManifestURI is the MCP resource URI of the agent manifest
+const ManifestURI = "artf://manifest"
+
+// SetManifest publishes the agent manifest as an MCP resource
+func (a *Agent) SetManifest(m *manifest.Manifest) {
+	resource := mcp.NewResource(ManifestURI, manifest.LabelKey,
+		mcp.WithResourceDescription("ARTF agent manifest: owner, intents, resources and dependencies of this agent"),
+		mcp.WithMIMEType("application/json"),
+	)
+	a.mcpServer.AddResource(resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: ManifestURI, MIMEType: "application/json", Text: string(data)},
+		}, nil
+	})
+}
+
 // GetFederationManager returns the federation manager
 func (a *Agent) GetFederationManager() *federation.Manager {
 	return a.federationManager
@@ -64,6 +88,7 @@ func NewAgent(grpcAgent *agent.ARTFAgent, addr string, port int) *Agent {
 		"ARTF Agent",
 		"0.10.0",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
 		server.WithRecovery(),
 	)
 
@@ -123,7 +148,7 @@ func (a *Agent) registerTools() {
 
 	// Define federation tools
 	listFederatedEndpointsTool := mcp.NewTool("list_federated_endpoints",
-		mcp.WithDescription("List all configured federated GRPC endpoints, their acceptable intents, health status, and configuration. Returns empty list if federation is not configured."),
+		mcp.WithDescription("List all configured federated GRPC endpoints, their acceptable intents, health status, admission status with the reasons an endpoint was refused or quarantined, and configuration. Returns empty list if federation is not configured."),
 	)
 
 	// Register tools with handlers
@@ -135,6 +160,11 @@ func (a *Agent) registerTools() {
 func (a *Agent) handleExtendRTB(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	startTime := time.Now()
 
+	ctx, span := tracing.Tracer().Start(ctx, "mcp extend_rtb",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("mcp.tool", "extend_rtb")))
+	defer span.End()
+
 	// Extract parameters
 	id, err := request.RequireString("id")
 	if err != nil {
@@ -160,27 +190,39 @@ func (a *Agent) handleExtendRTB(ctx context.Context, request mcp.CallToolRequest
 		bidResponseRaw = br
 	}
 
-	// Get optional lifecycle (NOTE: Full lifecycle enum requires proto regeneration)
+	// Get optional lifecycle
 	var lifecycleStr string
 	if lc, ok := args["lifecycle"].(string); ok && lc != "" {
 		lifecycleStr = lc
 	}
-	lifecycle := pb.Lifecycle_LIFECYCLE_UNSPECIFIED
+	lifecycle := parseLifecycle(lifecycleStr)
 
-	// Get optional originator (NOTE: Originator type requires proto regeneration)
+	// Get optional originator
 	var originatorStr string
+	var originator *pb.Originator
 	if orig, ok := args["originator"].(map[string]interface{}); ok {
+		originator = &pb.Originator{}
 		if t, ok := orig["type"].(string); ok {
 			originatorStr = t
+			originator.Type = parseOriginatorType(t).Enum()
+		}
+		if origID, ok := orig["id"].(string); ok {
+			originator.Id = &origID
 		}
 	}
 
 	// Get optional applicable_intents
 	var applicableIntentStrs []string
+	var applicableIntents []pb.Intent
 	if intentsRaw, ok := args["applicable_intents"].([]interface{}); ok {
 		for _, intentRaw := range intentsRaw {
 			if intentStr, ok := intentRaw.(string); ok {
+				intent := parseIntent(intentStr)
+				if intent == pb.Intent_INTENT_UNSPECIFIED {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid applicable_intents value: %s", intentStr)), nil
+				}
 				applicableIntentStrs = append(applicableIntentStrs, intentStr)
+				applicableIntents = append(applicableIntents, intent)
 			}
 		}
 	}
@@ -203,18 +245,22 @@ func (a *Agent) handleExtendRTB(ctx context.Context, request mcp.CallToolRequest
 	}
 
 	// Build the gRPC request
-	// NOTE: applicable_intents, originator, and full lifecycle enum require proto regeneration.
 	grpcRequest := &pb.RTBRequest{
-		Id:          &id,
-		Tmax:        &tmax,
-		Lifecycle:   lifecycle.Enum(),
-		BidRequest:  bidRequest,
-		BidResponse: bidResponse,
+		Id:                &id,
+		Tmax:              &tmax,
+		Lifecycle:         lifecycle.Enum(),
+		BidRequest:        bidRequest,
+		BidResponse:       bidResponse,
+		Originator:        originator,
+		ApplicableIntents: applicableIntents,
 	}
 
+	span.SetAttributes(tracing.RequestAttributes(grpcRequest)...)
+
 	// Call the gRPC agent directly (no network hop)
 	grpcResponse, err := a.grpcAgent.GetMutations(ctx, grpcRequest)
 	if err != nil {
+		tracing.RecordError(span, err)
 		log.Printf("MCP: Error from gRPC agent: %v", err)
 		return mcp.NewToolResultError(fmt.Sprintf("processing error: %v", err)), nil
 	}
@@ -242,31 +288,13 @@ func (a *Agent) handleExtendRTB(ctx context.Context, request mcp.CallToolRequest
 		var fedResponse *federation.FederatedResponse
 		if len(endpointNames) > 0 {
 			// Call specific endpoints
-			fedResponse = &federation.FederatedResponse{ID: id}
-			for _, epName := range endpointNames {
-				resp, err := a.federationManager.CallEndpoint(ctx, epName, grpcRequest)
-				if err != nil {
-					log.Printf("MCP: Federation endpoint '%s' error: %v", epName, err)
-					fedResponse.EndpointResults = append(fedResponse.EndpointResults, federation.FederatedResult{
-						EndpointName: epName,
-						Success:      false,
-						Error:        err.Error(),
-					})
-				} else {
-					fedResponse.Mutations = append(fedResponse.Mutations, resp.GetMutations()...)
-					fedResponse.EndpointResults = append(fedResponse.EndpointResults, federation.FederatedResult{
-						EndpointName: epName,
-						Success:      true,
-						Mutations:    resp.GetMutations(),
-					})
-				}
-			}
+			fedResponse, err = a.federationManager.CallEndpoints(ctx, grpcRequest, endpointNames, applicableIntentStrs)
 		} else {
 			// Call all applicable endpoints
 			fedResponse, err = a.federationManager.GetMutations(ctx, grpcRequest, applicableIntentStrs)
-			if err != nil {
-				log.Printf("MCP: Federation error: %v", err)
-			}
+		}
+		if err != nil {
+			log.Printf("MCP: Federation error: %v", err)
 		}
 
 		// Merge federated mutations with local mutations
@@ -285,6 +313,7 @@ func (a *Agent) handleExtendRTB(ctx context.Context, request mcp.CallToolRequest
 		return mcp.NewToolResultError(fmt.Sprintf("serialization error: %v", err)), nil
 	}
 
+	span.SetAttributes(attribute.Int("artf.mutations", len(grpcResponse.GetMutations())))
 	log.Printf("MCP: Request %s processed in %v, returning %d mutations",
 		id, time.Since(startTime), len(grpcResponse.GetMutations()))
 
@@ -317,8 +346,6 @@ func (a *Agent) handleListFederatedEndpoints(ctx context.Context, request mcp.Ca
 }
 
 // parseIntent converts a string to pb.Intent
-// NOTE: After proto regeneration, add parseLifecycle, parseOriginatorType functions
-// and ADD_CIDS case to this switch
 func parseIntent(s string) pb.Intent {
 	switch s {
 	case "ACTIVATE_SEGMENTS":
@@ -335,12 +362,41 @@ func parseIntent(s string) pb.Intent {
 		return pb.Intent_BID_SHADE
 	case "ADD_METRICS":
 		return pb.Intent_ADD_METRICS
-	// case "ADD_CIDS": return pb.Intent_ADD_CIDS // Requires proto regeneration
+	case "ADD_CIDS":
+		return pb.Intent_ADD_CIDS
 	default:
 		return pb.Intent_INTENT_UNSPECIFIED
 	}
 }
 
+// parseLifecycle converts a string to pb.Lifecycle
+func parseLifecycle(s string) pb.Lifecycle {
+	switch s {
+	case "LIFECYCLE_PUBLISHER_BID_REQUEST":
+		return pb.Lifecycle_LIFECYCLE_PUBLISHER_BID_REQUEST
+	case "LIFECYCLE_DSP_BID_RESPONSE":
+		return pb.Lifecycle_LIFECYCLE_DSP_BID_RESPONSE
+	default:
+		return pb.Lifecycle_LIFECYCLE_UNSPECIFIED
+	}
+}
+
+// parseOriginatorType converts a string to pb.Originator_Type
+func parseOriginatorType(s string) pb.Originator_Type {
+	switch s {
+	case "TYPE_PUBLISHER":
+		return pb.Originator_TYPE_PUBLISHER
+	case "TYPE_SSP":
+		return pb.Originator_TYPE_SSP
+	case "TYPE_EXCHANGE":
+		return pb.Originator_TYPE_EXCHANGE
+	case "TYPE_DSP":
+		return pb.Originator_TYPE_DSP
+	default:
+		return pb.Originator_TYPE_UNSPECIFIED
+	}
+}
+
 // jsonToOpenRTBBidRequest converts JSON map to OpenRTB BidRequest protobuf
 func jsonToOpenRTBBidRequest(data map[string]interface{}) (*openrtb.BidRequest, error) {
 	jsonBytes, err := json.Marshal(data)
@@ -387,7 +443,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Mcp-Session-Id, Last-Event-ID")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Mcp-Session-Id, Last-Event-ID, traceparent, tracestate, baggage")
 		w.Header().Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
 
 		// Handle preflight requests
@@ -406,7 +462,8 @@ func (a *Agent) Start() error {
 	log.Printf("MCP interface starting on %s", listenAddr)
 
 	// Create streamable HTTP server
-	streamableServer := server.NewStreamableHTTPServer(a.mcpServer)
+	streamableServer := server.NewStreamableHTTPServer(a.mcpServer,
+		server.WithHTTPContextFunc(tracing.HTTPContext))
 
 	// Create HTTP mux and wrap with CORS middleware
 	mux := http.NewServeMux()
@@ -431,6 +488,7 @@ func (a *Agent) GetMCPServer() *server.MCPServer {
 // Handler returns an HTTP handler for the MCP endpoint with CORS support.
 // This can be mounted on an existing mux to serve MCP alongside other routes.
 func (a *Agent) Handler() http.Handler {
-	streamableServer := server.NewStreamableHTTPServer(a.mcpServer)
+	streamableServer := server.NewStreamableHTTPServer(a.mcpServer,
+		server.WithHTTPContextFunc(tracing.HTTPContext))
 	return corsMiddleware(streamableServer)
 }
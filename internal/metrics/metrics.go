@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package metrics exposes mutation acceptance and federation latency metrics
+// in the Prometheus format
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "artf"
+
+// Metrics holds the agent's Prometheus collectors. It is the audit logger's
+// counter, so every mutation decision that is audited is also counted.
+type Metrics struct {
+	registry *prometheus.Registry
+	proposed *prometheus.CounterVec
+	accepted *prometheus.CounterVec
+	rejected *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// New creates the collectors and registers them, along with the Go runtime
+// and process collectors, on a dedicated registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		proposed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mutations_proposed_total",
+			Help:      "Mutations proposed by handlers and federated endpoints.",
+		}, []string{"source", "endpoint", "intent"}),
+		accepted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mutations_accepted_total",
+			Help:      "Proposed mutations returned to the caller.",
+		}, []string{"source", "endpoint", "intent"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mutations_rejected_total",
+			Help:      "Proposed mutations that were rejected, by stage and reason.",
+		}, []string{"source", "endpoint", "intent", "stage", "reason"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "federation_request_duration_seconds",
+			Help:      "Latency of GetMutations calls to federated endpoints.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"endpoint", "outcome"}),
+	}
+	m.registry.MustRegister(
+		m.proposed, m.accepted, m.rejected, m.latency,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// WatchAuditLogger exposes the number of audit records the logger dropped
+// because its queue was full
+func (m *Metrics) WatchAuditLogger(l *audit.Logger) {
+	m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "audit_records_dropped_total",
+		Help:      "Audit records dropped because the audit queue was full.",
+	}, func() float64 { return float64(l.Dropped()) }))
+}
+
+// Handler returns the HTTP handler serving the metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Count counts the proposed, accepted and rejected mutations in the records
+func (m *Metrics) Count(records []audit.Record) {
+	for _, r := range records {
+		m.proposed.WithLabelValues(r.Source, r.Endpoint, r.Intent).Inc()
+		if r.Accepted {
+			m.accepted.WithLabelValues(r.Source, r.Endpoint, r.Intent).Inc()
+			continue
+		}
+		reason := r.Code
+		if reason == "" {
+			reason = "unspecified"
+		}
+		m.rejected.WithLabelValues(r.Source, r.Endpoint, r.Intent, r.Stage, reason).Inc()
+	}
+}
+
+// ObserveEndpoint records the latency of a call to a federated endpoint
+func (m *Metrics) ObserveEndpoint(endpoint string, success bool, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	outcome := "success"
+	if !success {
+		outcome = "error"
+	}
+	m.latency.WithLabelValues(endpoint, outcome).Observe(latency.Seconds())
+}
@@ -0,0 +1,463 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package privacy decodes privacy signals carried in bid requests and decides
+// which handlers and federated endpoints may process a request
+package privacy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GPP section IDs decoded by this package
+const (
+	GPPSectionTCFEUv2 = 2
+	GPPSectionUSPv1   = 6
+	GPPSectionUSNat   = 7
+)
+
+// bitReader reads big-endian bit fields from a base64url encoded string,
+// as used by TCF and GPP. Padding and trailing partial bytes are tolerated.
+type bitReader struct {
+	bits []byte // one element per bit, 0 or 1
+	pos  int
+}
+
+// base64URLAlphabet maps 6-bit values to the websafe base64 alphabet
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// newBitReader decodes a base64url string into bits
+func newBitReader(s string) (*bitReader, error) {
+	s = strings.TrimRight(s, "=")
+	r := &bitReader{bits: make([]byte, 0, len(s)*6)}
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base64URLAlphabet, s[i])
+		if v < 0 {
+			return nil, fmt.Errorf("invalid base64url character %q", s[i])
+		}
+		for b := 5; b >= 0; b-- {
+			r.bits = append(r.bits, byte(v>>b)&1)
+		}
+	}
+	return r, nil
+}
+
+// int reads an unsigned integer of n bits
+func (r *bitReader) int(n int) (int64, error) {
+	if r.pos+n > len(r.bits) {
+		return 0, fmt.Errorf("string too short: need %d bits at offset %d, have %d", n, r.pos, len(r.bits))
+	}
+	var v int64
+	for i := 0; i < n; i++ {
+		v = v<<1 | int64(r.bits[r.pos+i])
+	}
+	r.pos += n
+	return v, nil
+}
+
+// bool reads a single bit
+func (r *bitReader) bool() (bool, error) {
+	v, err := r.int(1)
+	return v == 1, err
+}
+
+// bitfield reads n bits as a set of 1-based IDs
+func (r *bitReader) bitfield(n int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for i := 1; i <= n; i++ {
+		on, err := r.bool()
+		if err != nil {
+			return nil, err
+		}
+		if on {
+			set[i] = true
+		}
+	}
+	return set, nil
+}
+
+// letters reads a two-letter code encoded as 6-bit offsets from 'A'
+func (r *bitReader) letters() (string, error) {
+	a, err := r.int(6)
+	if err != nil {
+		return "", err
+	}
+	b, err := r.int(6)
+	if err != nil {
+		return "", err
+	}
+	return string([]byte{byte('A' + a), byte('A' + b)}), nil
+}
+
+// fibonacci reads a Fibonacci-coded integer, terminated by two consecutive 1 bits
+func (r *bitReader) fibonacci() (int, error) {
+	value, a, b := 0, 1, 2
+	prev := false
+	for {
+		bit, err := r.bool()
+		if err != nil {
+			return 0, err
+		}
+		if bit && prev {
+			return value, nil
+		}
+		if bit {
+			value += a
+		}
+		prev = bit
+		a, b = b, a+b
+	}
+}
+
+// TCString is a decoded IAB TCF v2 consent string (core segment)
+type TCString struct {
+	Version           int       `json:"version"`
+	Created           time.Time `json:"created"`
+	LastUpdated       time.Time `json:"last_updated"`
+	CMPID             int       `json:"cmp_id"`
+	CMPVersion        int       `json:"cmp_version"`
+	ConsentLanguage   string    `json:"consent_language"`
+	VendorListVersion int       `json:"vendor_list_version"`
+	PolicyVersion     int       `json:"policy_version"`
+	PublisherCC       string    `json:"publisher_cc"`
+
+	SpecialFeatureOptIns       map[int]bool `json:"special_feature_opt_ins,omitempty"`
+	PurposesConsent            map[int]bool `json:"purposes_consent,omitempty"`
+	PurposesLegitimateInterest map[int]bool `json:"purposes_legitimate_interest,omitempty"`
+	VendorConsents             map[int]bool `json:"vendor_consents,omitempty"`
+	VendorLegitimateInterests  map[int]bool `json:"vendor_legitimate_interests,omitempty"`
+}
+
+// consentOnlyPurposes cannot be processed on a legitimate interest basis under
+// TCF v2.2: storage and access (1) and personalised ads and content (3 to 6)
+var consentOnlyPurposes = map[int]bool{1: true, 3: true, 4: true, 5: true, 6: true}
+
+// AllowsPurpose reports whether a purpose has consent, or for purposes that
+// permit it, a legitimate interest basis
+func (t *TCString) AllowsPurpose(purpose int) bool {
+	if t.PurposesConsent[purpose] {
+		return true
+	}
+	return !consentOnlyPurposes[purpose] && t.PurposesLegitimateInterest[purpose]
+}
+
+// AllowsVendor reports whether a vendor has consent or a legitimate interest basis
+func (t *TCString) AllowsVendor(vendorID int) bool {
+	return t.VendorConsents[vendorID] || t.VendorLegitimateInterests[vendorID]
+}
+
+// DecodeTCString decodes the core segment of a TCF v2 consent string
+func DecodeTCString(s string) (*TCString, error) {
+	core := strings.SplitN(s, ".", 2)[0]
+	if core == "" {
+		return nil, fmt.Errorf("tcf: empty consent string")
+	}
+	r, err := newBitReader(core)
+	if err != nil {
+		return nil, fmt.Errorf("tcf: %w", err)
+	}
+	t, err := decodeTCCore(r)
+	if err != nil {
+		return nil, fmt.Errorf("tcf: %w", err)
+	}
+	return t, nil
+}
+
+// decodeTCCore reads the fields of the TCF v2 core segment in order
+func decodeTCCore(r *bitReader) (*TCString, error) {
+	t := &TCString{}
+	fields := []struct {
+		bits int
+		set  func(int64)
+	}{
+		{6, func(v int64) { t.Version = int(v) }},
+		{36, func(v int64) { t.Created = time.UnixMilli(v * 100).UTC() }},
+		{36, func(v int64) { t.LastUpdated = time.UnixMilli(v * 100).UTC() }},
+		{12, func(v int64) { t.CMPID = int(v) }},
+		{12, func(v int64) { t.CMPVersion = int(v) }},
+		{6, func(int64) {}}, // consent screen
+	}
+	for _, f := range fields {
+		v, err := r.int(f.bits)
+		if err != nil {
+			return nil, err
+		}
+		f.set(v)
+	}
+	if t.Version != 2 {
+		return nil, fmt.Errorf("unsupported version %d", t.Version)
+	}
+
+	var err error
+	if t.ConsentLanguage, err = r.letters(); err != nil {
+		return nil, err
+	}
+	vlv, err := r.int(12)
+	if err != nil {
+		return nil, err
+	}
+	t.VendorListVersion = int(vlv)
+	policy, err := r.int(6)
+	if err != nil {
+		return nil, err
+	}
+	t.PolicyVersion = int(policy)
+	if _, err := r.int(2); err != nil { // is service specific, use non-standard texts
+		return nil, err
+	}
+	if t.SpecialFeatureOptIns, err = r.bitfield(12); err != nil {
+		return nil, err
+	}
+	if t.PurposesConsent, err = r.bitfield(24); err != nil {
+		return nil, err
+	}
+	if t.PurposesLegitimateInterest, err = r.bitfield(24); err != nil {
+		return nil, err
+	}
+	if _, err := r.int(1); err != nil { // purpose one treatment
+		return nil, err
+	}
+	if t.PublisherCC, err = r.letters(); err != nil {
+		return nil, err
+	}
+	if t.VendorConsents, err = decodeVendorSection(r); err != nil {
+		return nil, fmt.Errorf("vendor consents: %w", err)
+	}
+	if t.VendorLegitimateInterests, err = decodeVendorSection(r); err != nil {
+		return nil, fmt.Errorf("vendor legitimate interests: %w", err)
+	}
+	return t, nil
+}
+
+// decodeVendorSection reads a vendor set encoded as a bitfield or as ranges.
+// Ranges may not cover more than maxVendor IDs in total, so overlapping
+// ranges cannot make decoding expensive.
+func decodeVendorSection(r *bitReader) (map[int]bool, error) {
+	maxVendor, err := r.int(16)
+	if err != nil {
+		return nil, err
+	}
+	isRange, err := r.bool()
+	if err != nil {
+		return nil, err
+	}
+	if !isRange {
+		return r.bitfield(int(maxVendor))
+	}
+
+	entries, err := r.int(12)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[int]bool)
+	var covered int64
+	for i := int64(0); i < entries; i++ {
+		isRange, err := r.bool()
+		if err != nil {
+			return nil, err
+		}
+		start, err := r.int(16)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = r.int(16); err != nil {
+				return nil, err
+			}
+		}
+		if end < start || end > maxVendor {
+			return nil, fmt.Errorf("invalid vendor range %d-%d", start, end)
+		}
+		if covered += end - start + 1; covered > maxVendor {
+			return nil, fmt.Errorf("vendor ranges cover more than %d IDs", maxVendor)
+		}
+		for id := start; id <= end; id++ {
+			set[int(id)] = true
+		}
+	}
+	return set, nil
+}
+
+// USPrivacy is a decoded IAB US Privacy (CCPA) string such as "1YNN".
+// Each flag is "Y", "N" or "-" (not applicable).
+type USPrivacy struct {
+	Version       int    `json:"version"`
+	Notice        string `json:"notice"`
+	OptOutSale    string `json:"opt_out_sale"`
+	LSPACoveredTx string `json:"lspa_covered"`
+}
+
+// OptedOut reports whether the user opted out of the sale of personal information
+func (u *USPrivacy) OptedOut() bool {
+	return u.OptOutSale == "Y"
+}
+
+// ParseUSPrivacy parses a four character US Privacy string
+func ParseUSPrivacy(s string) (*USPrivacy, error) {
+	if len(s) != 4 || s[0] != '1' {
+		return nil, fmt.Errorf("usp: invalid string %q", s)
+	}
+	for i := 1; i < 4; i++ {
+		if s[i] != 'Y' && s[i] != 'N' && s[i] != '-' {
+			return nil, fmt.Errorf("usp: invalid value %q at position %d", s[i], i)
+		}
+	}
+	return &USPrivacy{Version: 1, Notice: s[1:2], OptOutSale: s[2:3], LSPACoveredTx: s[3:4]}, nil
+}
+
+// USNational holds the opt-out fields of the GPP US National (usnat) section.
+// Values follow the GPP convention: 0 not applicable, 1 opted out, 2 did not opt out.
+type USNational struct {
+	Version                   int `json:"version"`
+	SaleOptOut                int `json:"sale_opt_out"`
+	SharingOptOut             int `json:"sharing_opt_out"`
+	TargetedAdvertisingOptOut int `json:"targeted_advertising_opt_out"`
+}
+
+// OptedOut reports whether the user opted out of sale, sharing or targeted advertising
+func (u *USNational) OptedOut() bool {
+	return u.SaleOptOut == 1 || u.SharingOptOut == 1 || u.TargetedAdvertisingOptOut == 1
+}
+
+// decodeUSNational reads the leading fields of a usnat core subsection
+func decodeUSNational(s string) (*USNational, error) {
+	r, err := newBitReader(strings.SplitN(s, ".", 2)[0])
+	if err != nil {
+		return nil, err
+	}
+	values := make([]int, 10) // version followed by six notices and three opt-outs
+	for i := range values {
+		bits := 2
+		if i == 0 {
+			bits = 6
+		}
+		v, err := r.int(bits)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = int(v)
+	}
+	return &USNational{
+		Version:                   values[0],
+		SaleOptOut:                values[7],
+		SharingOptOut:             values[8],
+		TargetedAdvertisingOptOut: values[9],
+	}, nil
+}
+
+// GPPString is a decoded IAB Global Privacy Platform string. Only the
+// TCF EU v2, US Privacy and US National sections are decoded; other
+// sections are kept as raw strings.
+type GPPString struct {
+	Version    int            `json:"version"`
+	SectionIDs []int          `json:"section_ids"`
+	Sections   map[int]string `json:"-"`
+	TCFEUv2    *TCString      `json:"tcf_eu_v2,omitempty"`
+	USPv1      *USPrivacy     `json:"usp_v1,omitempty"`
+	USNat      *USNational    `json:"usnat,omitempty"`
+}
+
+// DecodeGPP decodes a GPP string: a header followed by "~"-separated sections
+func DecodeGPP(s string) (*GPPString, error) {
+	parts := strings.Split(s, "~")
+	r, err := newBitReader(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("gpp: header: %w", err)
+	}
+	typ, err := r.int(6)
+	if err != nil {
+		return nil, fmt.Errorf("gpp: header: %w", err)
+	}
+	if typ != 3 {
+		return nil, fmt.Errorf("gpp: invalid header type %d", typ)
+	}
+	version, err := r.int(6)
+	if err != nil {
+		return nil, fmt.Errorf("gpp: header: %w", err)
+	}
+	ids, err := decodeFibonacciRange(r, len(parts)-1)
+	if err != nil {
+		return nil, fmt.Errorf("gpp: section ids: %w", err)
+	}
+	if len(ids) != len(parts)-1 {
+		return nil, fmt.Errorf("gpp: header lists %d sections, string has %d", len(ids), len(parts)-1)
+	}
+
+	g := &GPPString{Version: int(version), SectionIDs: ids, Sections: make(map[int]string)}
+	for i, id := range ids {
+		section := parts[i+1]
+		g.Sections[id] = section
+		switch id {
+		case GPPSectionTCFEUv2:
+			if g.TCFEUv2, err = DecodeTCString(section); err != nil {
+				return nil, fmt.Errorf("gpp: section %d: %w", id, err)
+			}
+		case GPPSectionUSPv1:
+			if g.USPv1, err = ParseUSPrivacy(section); err != nil {
+				return nil, fmt.Errorf("gpp: section %d: %w", id, err)
+			}
+		case GPPSectionUSNat:
+			if g.USNat, err = decodeUSNational(section); err != nil {
+				return nil, fmt.Errorf("gpp: section %d: %w", id, err)
+			}
+		}
+	}
+	return g, nil
+}
+
+// decodeFibonacciRange reads a GPP Fibonacci-coded range of IDs. Each entry
+// is an offset from the previous ID, optionally followed by a range length.
+// Decoding stops with an error once more than max IDs are listed, so a short
+// string cannot expand into an arbitrarily large list.
+func decodeFibonacciRange(r *bitReader, max int) ([]int, error) {
+	count, err := r.int(12)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	last := 0
+	for i := int64(0); i < count; i++ {
+		isRange, err := r.bool()
+		if err != nil {
+			return nil, err
+		}
+		offset, err := r.fibonacci()
+		if err != nil {
+			return nil, err
+		}
+		start := last + offset
+		end := start
+		if isRange {
+			length, err := r.fibonacci()
+			if err != nil {
+				return nil, err
+			}
+			end = start + length
+		}
+		if end < start || end-start+1 > max-len(ids) {
+			return nil, fmt.Errorf("ranges list more than %d IDs", max)
+		}
+		for id := start; id <= end; id++ {
+			ids = append(ids, id)
+		}
+		last = end
+	}
+	return ids, nil
+}
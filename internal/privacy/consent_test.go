@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package privacy
+
+import (
+	"strings"
+	"testing"
+)
+
+// bitWriter builds base64url encoded bit strings for test consent strings
+type bitWriter struct {
+	bits []byte
+}
+
+func (w *bitWriter) int(v int64, n int) {
+	for b := n - 1; b >= 0; b-- {
+		w.bits = append(w.bits, byte(v>>b)&1)
+	}
+}
+
+func (w *bitWriter) bitfield(n int, ids ...int) {
+	set := make(map[int]bool)
+	for _, id := range ids {
+		set[id] = true
+	}
+	for i := 1; i <= n; i++ {
+		if set[i] {
+			w.int(1, 1)
+		} else {
+			w.int(0, 1)
+		}
+	}
+}
+
+func (w *bitWriter) String() string {
+	var sb strings.Builder
+	for i := 0; i < len(w.bits); i += 6 {
+		v := 0
+		for j := 0; j < 6; j++ {
+			v <<= 1
+			if i+j < len(w.bits) {
+				v |= int(w.bits[i+j])
+			}
+		}
+		sb.WriteByte(base64URLAlphabet[v])
+	}
+	return sb.String()
+}
+
+// tcCore writes a TCF v2 core segment up to the vendor sections
+func tcCore(consents, legitimateInterests []int) *bitWriter {
+	w := &bitWriter{}
+	w.int(2, 6)            // version
+	w.int(16000000000, 36) // created
+	w.int(16000000000, 36) // last updated
+	w.int(7, 12)           // CMP ID
+	w.int(1, 12)           // CMP version
+	w.int(1, 6)            // consent screen
+	w.int(4, 6)            // consent language "EN"
+	w.int(13, 6)
+	w.int(100, 12) // vendor list version
+	w.int(4, 6)    // policy version
+	w.int(0, 2)    // is service specific, use non-standard texts
+	w.bitfield(12) // special feature opt-ins
+	w.bitfield(24, consents...)
+	w.bitfield(24, legitimateInterests...)
+	w.int(0, 1) // purpose one treatment
+	w.int(3, 6) // publisher country "DE"
+	w.int(4, 6)
+	return w
+}
+
+// emptyVendorSection writes a vendor bitfield with no vendors
+func emptyVendorSection(w *bitWriter) {
+	w.int(0, 16)
+	w.int(0, 1)
+}
+
+func TestAllowsPurpose(t *testing.T) {
+	w := tcCore([]int{1}, []int{2, 4, 7})
+	emptyVendorSection(w)
+	emptyVendorSection(w)
+
+	tc, err := DecodeTCString(w.String())
+	if err != nil {
+		t.Fatalf("DecodeTCString: %v", err)
+	}
+
+	tests := []struct {
+		purpose int
+		want    bool
+	}{
+		{1, true},  // consent
+		{2, true},  // legitimate interest
+		{3, false}, // neither
+		{4, false}, // legitimate interest is not a basis for personalised ads
+		{7, true},  // legitimate interest
+	}
+	for _, tt := range tests {
+		if got := tc.AllowsPurpose(tt.purpose); got != tt.want {
+			t.Errorf("AllowsPurpose(%d) = %v, want %v", tt.purpose, got, tt.want)
+		}
+	}
+}
+
+// vendorRanges writes a range-encoded vendor section
+func vendorRanges(w *bitWriter, maxVendor int64, ranges [][2]int64) {
+	w.int(maxVendor, 16)
+	w.int(1, 1)
+	w.int(int64(len(ranges)), 12)
+	for _, rg := range ranges {
+		if rg[0] == rg[1] {
+			w.int(0, 1)
+			w.int(rg[0], 16)
+			continue
+		}
+		w.int(1, 1)
+		w.int(rg[0], 16)
+		w.int(rg[1], 16)
+	}
+}
+
+func TestDecodeVendorRanges(t *testing.T) {
+	w := tcCore(nil, nil)
+	vendorRanges(w, 100, [][2]int64{{1, 10}, {42, 42}})
+	emptyVendorSection(w)
+
+	tc, err := DecodeTCString(w.String())
+	if err != nil {
+		t.Fatalf("DecodeTCString: %v", err)
+	}
+	for _, id := range []int{1, 10, 42} {
+		if !tc.AllowsVendor(id) {
+			t.Errorf("AllowsVendor(%d) = false, want true", id)
+		}
+	}
+	for _, id := range []int{11, 41, 43} {
+		if tc.AllowsVendor(id) {
+			t.Errorf("AllowsVendor(%d) = true, want false", id)
+		}
+	}
+}
+
+func TestDecodeVendorRangesOverlapping(t *testing.T) {
+	ranges := make([][2]int64, 4095)
+	for i := range ranges {
+		ranges[i] = [2]int64{1, 65535}
+	}
+	w := tcCore(nil, nil)
+	vendorRanges(w, 65535, ranges)
+	emptyVendorSection(w)
+
+	if _, err := DecodeTCString(w.String()); err == nil {
+		t.Fatal("DecodeTCString accepted ranges covering more than maxVendor IDs")
+	}
+}
+
+func TestDecodeGPP(t *testing.T) {
+	g, err := DecodeGPP("DBABTA~1YNN")
+	if err != nil {
+		t.Fatalf("DecodeGPP: %v", err)
+	}
+	if len(g.SectionIDs) != 1 || g.SectionIDs[0] != GPPSectionUSPv1 || g.USPv1 == nil {
+		t.Errorf("DecodeGPP = %+v, want a single US Privacy section", g)
+	}
+}
+
+func TestDecodeGPPSectionRange(t *testing.T) {
+	// A header whose section ID range lists about 50 million sections must be
+	// rejected while decoding the range, not after expanding it
+	_, err := DecodeGPP("DBABupVQgkT~1YNN")
+	if err == nil || !strings.Contains(err.Error(), "more than 1 IDs") {
+		t.Fatalf("DecodeGPP error = %v, want the range rejected", err)
+	}
+}
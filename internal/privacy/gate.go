@@ -0,0 +1,388 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package privacy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Subject kinds a decision applies to
+const (
+	KindHandler  = "handler"
+	KindEndpoint = "endpoint"
+)
+
+// Scrub actions applied to the request before it is processed
+const (
+	// ScrubTruncateIP zeroes the host part of device IPs (last IPv4 octet, last 80 IPv6 bits)
+	ScrubTruncateIP = "truncate_ip"
+
+	// ScrubUserIDs removes user and device identifiers
+	ScrubUserIDs = "remove_user_ids"
+
+	// ScrubPreciseGeo removes latitude and longitude
+	ScrubPreciseGeo = "remove_precise_geo"
+)
+
+// Requirements describe what a handler or endpoint needs to process a request
+type Requirements struct {
+	// Purposes are the TCF purposes that must be allowed when GDPR applies
+	Purposes []int `json:"purposes,omitempty" yaml:"purposes,omitempty"`
+
+	// VendorID is the TCF vendor that must be allowed when GDPR applies (0 to skip)
+	VendorID int `json:"vendor_id,omitempty" yaml:"vendor_id,omitempty"`
+
+	// Sale marks processing that is a sale, sharing or targeted advertising
+	// under US privacy laws; it is blocked when the user opted out
+	Sale bool `json:"sale,omitempty" yaml:"sale,omitempty"`
+
+	// AllowChildren permits processing of COPPA-regulated requests
+	AllowChildren bool `json:"allow_children,omitempty" yaml:"allow_children,omitempty"`
+}
+
+// Config contains the privacy requirements of handlers and endpoints.
+// Subjects without an entry use the defaults.
+type Config struct {
+	Version   string                  `json:"version,omitempty" yaml:"version,omitempty"`
+	Defaults  Requirements            `json:"defaults" yaml:"defaults"`
+	Handlers  map[string]Requirements `json:"handlers,omitempty" yaml:"handlers,omitempty"`
+	Endpoints map[string]Requirements `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+}
+
+// LoadConfig loads a privacy configuration from a YAML or JSON file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read privacy config: %w", err)
+	}
+	return ParseConfig(data, path)
+}
+
+// ParseConfig parses a privacy configuration; JSON is used for .json files, YAML otherwise
+func ParseConfig(data []byte, path string) (*Config, error) {
+	var config Config
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse privacy config: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse privacy config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks the configuration for errors
+func (c *Config) Validate() error {
+	check := func(name string, r Requirements) error {
+		for _, p := range r.Purposes {
+			if p < 1 || p > 24 {
+				return fmt.Errorf("%s: invalid TCF purpose %d", name, p)
+			}
+		}
+		if r.VendorID < 0 {
+			return fmt.Errorf("%s: invalid vendor_id %d", name, r.VendorID)
+		}
+		return nil
+	}
+	if err := check("defaults", c.Defaults); err != nil {
+		return err
+	}
+	for name, r := range c.Handlers {
+		if err := check("handler '"+name+"'", r); err != nil {
+			return err
+		}
+	}
+	for name, r := range c.Endpoints {
+		if err := check("endpoint '"+name+"'", r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signals are the privacy signals found in a bid request
+type Signals struct {
+	GDPR      bool       `json:"gdpr"`
+	COPPA     bool       `json:"coppa"`
+	TCF       *TCString  `json:"tcf,omitempty"`
+	USPrivacy *USPrivacy `json:"us_privacy,omitempty"`
+	GPP       *GPPString `json:"gpp,omitempty"`
+	GPPSID    []int      `json:"gpp_sid,omitempty"`
+
+	// Errors lists consent strings that were present but could not be decoded
+	Errors []string `json:"errors,omitempty"`
+}
+
+// USOptOut reports whether the user opted out of sale, sharing or targeted
+// advertising through the US Privacy string or an applicable GPP section
+func (s *Signals) USOptOut() bool {
+	if s.USPrivacy != nil && s.USPrivacy.OptedOut() {
+		return true
+	}
+	if s.GPP == nil {
+		return false
+	}
+	if s.GPP.USPv1 != nil && s.gppApplies(GPPSectionUSPv1) && s.GPP.USPv1.OptedOut() {
+		return true
+	}
+	return s.GPP.USNat != nil && s.gppApplies(GPPSectionUSNat) && s.GPP.USNat.OptedOut()
+}
+
+// gppApplies reports whether a GPP section applies; without gpp_sid every section does
+func (s *Signals) gppApplies(section int) bool {
+	if len(s.GPPSID) == 0 {
+		return true
+	}
+	for _, id := range s.GPPSID {
+		if id == section {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSignals reads and decodes the privacy signals in a bid request.
+// Undecodable consent strings are recorded in Errors and treated as absent.
+func ParseSignals(br *openrtb.BidRequest) *Signals {
+	regs := br.GetRegs()
+	s := &Signals{
+		GDPR:  regs.GetGdpr(),
+		COPPA: regs.GetCoppa(),
+	}
+	for _, sid := range regs.GetGppSid() {
+		s.GPPSID = append(s.GPPSID, int(sid))
+	}
+
+	if gpp := regs.GetGpp(); gpp != "" {
+		decoded, err := DecodeGPP(gpp)
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+		} else {
+			s.GPP = decoded
+		}
+	}
+	if usp := regs.GetUsPrivacy(); usp != "" {
+		decoded, err := ParseUSPrivacy(usp)
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+		} else {
+			s.USPrivacy = decoded
+		}
+	}
+	if consent := br.GetUser().GetConsent(); consent != "" {
+		decoded, err := DecodeTCString(consent)
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+		} else {
+			s.TCF = decoded
+		}
+	}
+
+	// GPP carries GDPR consent in its TCF EU section
+	if s.GPP != nil && s.GPP.TCFEUv2 != nil && s.gppApplies(GPPSectionTCFEUv2) {
+		if len(s.GPPSID) > 0 {
+			s.GDPR = true
+		}
+		if s.TCF == nil {
+			s.TCF = s.GPP.TCFEUv2
+		}
+	}
+	return s
+}
+
+// Decision records whether a handler or endpoint may process a request
+type Decision struct {
+	RequestID string `json:"request_id"`
+	Kind      string `json:"kind"`
+	Subject   string `json:"subject"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason"`
+}
+
+// Assessment is the privacy evaluation of a single request
+type Assessment struct {
+	RequestID string   `json:"request_id"`
+	Signals   *Signals `json:"signals"`
+	Scrubs    []string `json:"scrubs,omitempty"`
+}
+
+// Gate decides which handlers and endpoints may process a request and which
+// fields are scrubbed first. Every decision is logged for audit.
+type Gate struct {
+	config *Config
+}
+
+// NewGate creates a privacy gate from configuration
+func NewGate(config *Config) *Gate {
+	return &Gate{config: config}
+}
+
+// NewGateFromFile creates a privacy gate from a configuration file
+func NewGateFromFile(path string) (*Gate, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGate(config), nil
+}
+
+// Assess decodes the request's privacy signals and decides which fields must be scrubbed
+func (g *Gate) Assess(req *pb.RTBRequest) *Assessment {
+	a := &Assessment{
+		RequestID: req.GetId(),
+		Signals:   ParseSignals(req.GetBidRequest()),
+	}
+	s := a.Signals
+	for _, e := range s.Errors {
+		log.Printf("[Privacy] Request %s has an invalid consent string: %s", a.RequestID, e)
+	}
+
+	scrub := make(map[string]bool)
+	if s.COPPA {
+		scrub[ScrubTruncateIP], scrub[ScrubUserIDs], scrub[ScrubPreciseGeo] = true, true, true
+	}
+	if s.GDPR && (s.TCF == nil || !s.TCF.AllowsPurpose(1)) {
+		scrub[ScrubTruncateIP], scrub[ScrubUserIDs] = true, true
+	}
+	if s.USOptOut() {
+		scrub[ScrubUserIDs] = true
+	}
+	for _, action := range []string{ScrubTruncateIP, ScrubUserIDs, ScrubPreciseGeo} {
+		if scrub[action] {
+			a.Scrubs = append(a.Scrubs, action)
+		}
+	}
+
+	log.Printf("[Privacy] Request %s signals gdpr=%v tcf=%v coppa=%v us_opt_out=%v scrubs=%v",
+		a.RequestID, s.GDPR, s.TCF != nil, s.COPPA, s.USOptOut(), a.Scrubs)
+	return a
+}
+
+// Allow decides whether a handler or endpoint may process the assessed request
+func (g *Gate) Allow(a *Assessment, kind, name string) Decision {
+	d := Decision{RequestID: a.RequestID, Kind: kind, Subject: name}
+	d.Allowed, d.Reason = g.allow(a.Signals, g.requirements(kind, name))
+
+	verdict := "allowed"
+	if !d.Allowed {
+		verdict = "denied"
+	}
+	log.Printf("[Privacy] Request %s %s '%s' %s: %s", d.RequestID, kind, name, verdict, d.Reason)
+	return d
+}
+
+// requirements returns the configured requirements for a subject, or the defaults
+func (g *Gate) requirements(kind, name string) Requirements {
+	subjects := g.config.Handlers
+	if kind == KindEndpoint {
+		subjects = g.config.Endpoints
+	}
+	if r, ok := subjects[name]; ok {
+		return r
+	}
+	return g.config.Defaults
+}
+
+// allow applies the COPPA, GDPR and US privacy rules in that order
+func (g *Gate) allow(s *Signals, r Requirements) (bool, string) {
+	if s.COPPA && !r.AllowChildren {
+		return false, "coppa: processing of child-directed requests not permitted"
+	}
+	if s.GDPR && (len(r.Purposes) > 0 || r.VendorID > 0) {
+		if s.TCF == nil {
+			return false, "gdpr: no valid TCF consent string"
+		}
+		for _, p := range r.Purposes {
+			if !s.TCF.AllowsPurpose(p) {
+				return false, fmt.Sprintf("gdpr: purpose %d not allowed", p)
+			}
+		}
+		if r.VendorID > 0 && !s.TCF.AllowsVendor(r.VendorID) {
+			return false, fmt.Sprintf("gdpr: vendor %d not allowed", r.VendorID)
+		}
+	}
+	if r.Sale && s.USOptOut() {
+		return false, "us privacy: user opted out of sale or sharing"
+	}
+	return true, "requirements met"
+}
+
+// Scrub returns a copy of the request with the assessment's scrub actions
+// applied, or the request itself if nothing needs scrubbing
+func Scrub(req *pb.RTBRequest, a *Assessment) *pb.RTBRequest {
+	if a == nil || len(a.Scrubs) == 0 || req.GetBidRequest() == nil {
+		return req
+	}
+	scrubbed := proto.Clone(req).(*pb.RTBRequest)
+	br := scrubbed.GetBidRequest()
+	for _, action := range a.Scrubs {
+		switch action {
+		case ScrubTruncateIP:
+			if d := br.GetDevice(); d != nil {
+				if d.Ip != nil {
+					d.Ip = proto.String(truncateIP(d.GetIp(), 24, 32))
+				}
+				if d.Ipv6 != nil {
+					d.Ipv6 = proto.String(truncateIP(d.GetIpv6(), 48, 128))
+				}
+			}
+		case ScrubUserIDs:
+			if u := br.GetUser(); u != nil {
+				u.Id, u.Buyeruid, u.Eids = nil, nil, nil
+			}
+			if d := br.GetDevice(); d != nil {
+				d.Ifa, d.Didsha1, d.Didmd5, d.Dpidsha1, d.Dpidmd5, d.Macsha1, d.Macmd5 = nil, nil, nil, nil, nil, nil, nil
+			}
+		case ScrubPreciseGeo:
+			for _, geo := range []*openrtb.BidRequest_Geo{br.GetDevice().GetGeo(), br.GetUser().GetGeo()} {
+				if geo != nil {
+					geo.Lat, geo.Lon = nil, nil
+				}
+			}
+		}
+	}
+	return scrubbed
+}
+
+// truncateIP keeps the leading prefix bits of an address; unparsable addresses are removed
+func truncateIP(address string, prefix, bits int) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+	if bits == 32 {
+		ip = ip.To4()
+		if ip == nil {
+			return ""
+		}
+	}
+	return ip.Mask(net.CIDRMask(prefix, bits)).String()
+}
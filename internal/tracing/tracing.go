@@ -0,0 +1,232 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package tracing configures OpenTelemetry tracing for the agent and its
+// federated calls
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+)
+
+// Supported span exporters
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// instrumentationName identifies the spans created by this module
+const instrumentationName = "github.com/iabtechlab/agentic-rtb-framework"
+
+// Config selects where spans are exported
+type Config struct {
+	// Exporter is one of none, otlp, stdout or file
+	Exporter string
+
+	// Endpoint is the OTLP gRPC collector address. If empty, the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4317 is used.
+	Endpoint string
+
+	// Insecure disables TLS to the OTLP collector
+	Insecure bool
+
+	// File receives spans as JSON lines when Exporter is file
+	File string
+
+	// SampleRatio is the fraction of root traces sampled. Child spans follow
+	// the sampling decision of their parent.
+	SampleRatio float64
+
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup installs the global tracer provider and the W3C trace context and
+// baggage propagators. The propagators are installed even when exporting is
+// disabled, so trace context from callers still reaches federated endpoints.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	noop := func(context.Context) error { return nil }
+	var (
+		exporter sdktrace.SpanExporter
+		file     io.Closer
+		err      error
+	)
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return noop, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		if cfg.File == "" {
+			return nil, fmt.Errorf("trace exporter 'file' requires a file path")
+		}
+		f, openErr := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to open trace file: %w", openErr)
+		}
+		file = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter '%s' (expected %s, %s, %s or %s)",
+			cfg.Exporter, ExporterNone, ExporterOTLP, ExporterStdout, ExporterFile)
+	}
+	if err != nil {
+		if file != nil {
+			file.Close()
+		}
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer for the agent's spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// ServerHandler returns the gRPC server stats handler that extracts trace
+// context from incoming metadata. Health checks are not traced.
+func ServerHandler() stats.Handler {
+	return otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))
+}
+
+// ClientHandler returns the gRPC client stats handler that injects trace
+// context into outgoing metadata. Health checks are not traced.
+func ClientHandler() stats.Handler {
+	return otelgrpc.NewClientHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))
+}
+
+// HTTPContext extracts the trace context of an incoming HTTP request, such as
+// an MCP tool call
+func HTTPContext(ctx context.Context, r *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+}
+
+// RequestAttributes describes an RTB request on a span
+func RequestAttributes(req *pb.RTBRequest) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("artf.request.id", req.GetId()),
+		attribute.String("artf.lifecycle", req.GetLifecycle().String()),
+		attribute.String("artf.originator.type", req.GetOriginator().GetType().String()),
+		attribute.Int("artf.tmax", int(req.GetTmax())),
+	}
+}
+
+// RecordError marks the span as failed
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectRequest returns a copy of req that carries the trace context of ctx in
+// its ext, for requests sent on a StreamMutations stream. req is left unchanged.
+func InjectRequest(ctx context.Context, req *pb.RTBRequest) *pb.RTBRequest {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return req
+	}
+
+	out := proto.Clone(req).(*pb.RTBRequest)
+	if out.Ext == nil {
+		out.Ext = &pb.RTBRequest_Ext{}
+	}
+	proto.SetExtension(out.Ext, pb.E_TraceContext, &pb.TraceContext{Headers: carrier})
+	return out
+}
+
+// ExtractRequest returns ctx with the trace context carried in the ext of a
+// request received on a StreamMutations stream, if there is one
+func ExtractRequest(ctx context.Context, req *pb.RTBRequest) context.Context {
+	ext := req.GetExt()
+	if ext == nil || !proto.HasExtension(ext, pb.E_TraceContext) {
+		return ctx
+	}
+	tc := proto.GetExtension(ext, pb.E_TraceContext).(*pb.TraceContext)
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(tc.GetHeaders()))
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package validate checks that mutations in an RTBResponse conform to the
+// intent, operation and payload combinations defined by the ARTF specification.
+// Conformance is checked without the bid request, so it can run on any
+// response before the mutations are applied.
+package validate
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+)
+
+// Violation describes a non-conforming mutation
+type Violation struct {
+	// Index is the position of the mutation in the response
+	Index int `json:"index"`
+
+	// Intent is the intent declared by the mutation
+	Intent pb.Intent `json:"intent"`
+
+	// Reason classifies the violation
+	Reason patch.Reason `json:"reason"`
+
+	// Detail is a human-readable explanation
+	Detail string `json:"detail"`
+
+	// Mutation is the dropped mutation
+	Mutation *pb.Mutation `json:"-"`
+}
+
+// String returns a short description of the violation
+func (v Violation) String() string {
+	return fmt.Sprintf("mutation %d (%v): %s: %s", v.Index, v.Intent, v.Reason, v.Detail)
+}
+
+// Mutation checks that a single mutation has a legal intent, operation, path
+// and payload combination. It returns nil if the mutation conforms.
+func Mutation(m *pb.Mutation) *patch.RejectionError {
+	if m == nil {
+		return &patch.RejectionError{Reason: patch.ReasonInvalidPayload, Detail: "mutation is nil"}
+	}
+	if _, err := patch.CheckPath(m.GetIntent(), m.GetOp(), m.GetPath()); err != nil {
+		return err
+	}
+	return checkPayload(m)
+}
+
+// Response checks every mutation in a response and returns the violations
+func Response(resp *pb.RTBResponse) []Violation {
+	_, violations := Filter(resp.GetMutations())
+	return violations
+}
+
+// Filter returns the conforming mutations in order, and a violation for each
+// mutation that was dropped
+func Filter(mutations []*pb.Mutation) ([]*pb.Mutation, []Violation) {
+	var valid []*pb.Mutation
+	var violations []Violation
+	for i, m := range mutations {
+		if err := Mutation(m); err != nil {
+			violations = append(violations, Violation{
+				Index:    i,
+				Intent:   m.GetIntent(),
+				Reason:   err.Reason,
+				Detail:   err.Detail,
+				Mutation: m,
+			})
+			continue
+		}
+		valid = append(valid, m)
+	}
+	return valid, violations
+}
+
+// ReasonIntentNotApplicable means the mutation's intent is not one the source
+// is allowed to emit
+const ReasonIntentNotApplicable patch.Reason = "intent_not_applicable"
+
+// FilterIntents returns the mutations whose intent is allowed, in order, and a
+// violation for each mutation that was dropped
+func FilterIntents(mutations []*pb.Mutation, allowed func(pb.Intent) bool) ([]*pb.Mutation, []Violation) {
+	var valid []*pb.Mutation
+	var violations []Violation
+	for i, m := range mutations {
+		if !allowed(m.GetIntent()) {
+			violations = append(violations, Violation{
+				Index:    i,
+				Intent:   m.GetIntent(),
+				Reason:   ReasonIntentNotApplicable,
+				Detail:   fmt.Sprintf("%v is not an applicable intent", m.GetIntent()),
+				Mutation: m,
+			})
+			continue
+		}
+		valid = append(valid, m)
+	}
+	return valid, violations
+}
+
+// checkPayload checks that the payload type and required fields match the intent
+func checkPayload(m *pb.Mutation) *patch.RejectionError {
+	switch m.GetIntent() {
+	case pb.Intent_ACTIVATE_SEGMENTS, pb.Intent_ACTIVATE_DEALS, pb.Intent_SUPPRESS_DEALS:
+		ids := m.GetIds()
+		if ids == nil {
+			return mismatch("%v requires IDsPayload", m.GetIntent())
+		}
+		if len(ids.GetId()) == 0 {
+			return invalid("ID list is empty")
+		}
+	case pb.Intent_ADJUST_DEAL_FLOOR:
+		adjust := m.GetAdjustDeal()
+		if adjust == nil {
+			return mismatch("ADJUST_DEAL_FLOOR requires AdjustDealPayload")
+		}
+		if adjust.Bidfloor == nil {
+			return invalid("bidfloor is required")
+		}
+	case pb.Intent_ADJUST_DEAL_MARGIN:
+		adjust := m.GetAdjustDeal()
+		if adjust == nil {
+			return mismatch("ADJUST_DEAL_MARGIN requires AdjustDealPayload")
+		}
+		if adjust.GetMargin() == nil {
+			return invalid("margin is required")
+		}
+	case pb.Intent_BID_SHADE:
+		adjust := m.GetAdjustBid()
+		if adjust == nil {
+			return mismatch("BID_SHADE requires AdjustBidPayload")
+		}
+		if adjust.Price == nil {
+			return invalid("price is required")
+		}
+	case pb.Intent_ADD_METRICS:
+		metrics := m.GetMetrics()
+		if metrics == nil {
+			return mismatch("ADD_METRICS requires MetricsPayload")
+		}
+		if len(metrics.GetMetric()) == 0 {
+			return invalid("metric list is empty")
+		}
+	case pb.Intent_ADD_CIDS:
+		data := m.GetContentData()
+		if data == nil {
+			return mismatch("ADD_CIDS requires DataPayload")
+		}
+		if len(data.GetData()) == 0 {
+			return invalid("data list is empty")
+		}
+	}
+	return nil
+}
+
+func mismatch(format string, args ...interface{}) *patch.RejectionError {
+	return &patch.RejectionError{Reason: patch.ReasonPayloadMismatch, Detail: fmt.Sprintf(format, args...)}
+}
+
+func invalid(format string, args ...interface{}) *patch.RejectionError {
+	return &patch.RejectionError{Reason: patch.ReasonInvalidPayload, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Counter keeps running totals of dropped mutations by reason.
+// It is safe for concurrent use.
+type Counter struct {
+	mu     sync.Mutex
+	counts map[patch.Reason]uint64
+	total  uint64
+}
+
+// NewCounter creates an empty counter
+func NewCounter() *Counter {
+	return &Counter{counts: make(map[patch.Reason]uint64)}
+}
+
+// Add records the given violations
+func (c *Counter) Add(violations []Violation) {
+	if len(violations) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range violations {
+		c.counts[v.Reason]++
+		c.total++
+	}
+}
+
+// Total returns the number of dropped mutations
+func (c *Counter) Total() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// Count returns the number of mutations dropped for the given reason
+func (c *Counter) Count(reason patch.Reason) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[reason]
+}
+
+// ByReason returns a copy of the dropped mutation counts keyed by reason
+func (c *Counter) ByReason() map[string]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[string]uint64, len(c.counts))
+	for reason, n := range c.counts {
+		counts[string(reason)] = n
+	}
+	return counts
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/iabtechlab/agentic-rtb-framework/pkg/patch"
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"google.golang.org/protobuf/proto"
+)
+
+func idsMutation(intent pb.Intent, op pb.Operation, path string, ids ...string) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: intent.Enum(),
+		Op:     op.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_Ids{Ids: &pb.IDsPayload{Id: ids}},
+	}
+}
+
+func dealMutation(intent pb.Intent, path string, payload *pb.AdjustDealPayload) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: intent.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_AdjustDeal{AdjustDeal: payload},
+	}
+}
+
+func shadeMutation(price *float64) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_BID_SHADE.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String("/seatbid/seat-1/bid/bid-1"),
+		Value:  &pb.Mutation_AdjustBid{AdjustBid: &pb.AdjustBidPayload{Price: price}},
+	}
+}
+
+func metricsMutation(metrics ...*openrtb.BidRequest_Imp_Metric) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADD_METRICS.Enum(),
+		Op:     pb.Operation_OPERATION_ADD.Enum(),
+		Path:   proto.String("/imp/1/metric"),
+		Value:  &pb.Mutation_Metrics{Metrics: &pb.MetricsPayload{Metric: metrics}},
+	}
+}
+
+func contentDataMutation(data ...*openrtb.BidRequest_Data) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADD_CIDS.Enum(),
+		Op:     pb.Operation_OPERATION_ADD.Enum(),
+		Path:   proto.String("/site/content/data"),
+		Value:  &pb.Mutation_ContentData{ContentData: &pb.DataPayload{Data: data}},
+	}
+}
+
+func TestMutation(t *testing.T) {
+	margin := &pb.Margin{Value: proto.Float64(10), CalculationType: pb.Margin_PERCENT.Enum()}
+	tests := []struct {
+		name     string
+		mutation *pb.Mutation
+		want     patch.Reason
+	}{
+		// Conforming mutations for every intent
+		{"segments", idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", "seg-1"), ""},
+		{"activate deals", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1", "deal-a"), ""},
+		{"suppress deals", idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1/pmp/deals", "deal-a"), ""},
+		{"deal floor", dealMutation(pb.Intent_ADJUST_DEAL_FLOOR, "/imp/1/pmp/deals/deal-a", &pb.AdjustDealPayload{Bidfloor: proto.Float64(1)}), ""},
+		{"deal margin", dealMutation(pb.Intent_ADJUST_DEAL_MARGIN, "/imp/1/pmp/deals", &pb.AdjustDealPayload{Margin: margin}), ""},
+		{"bid shade", shadeMutation(proto.Float64(1.5)), ""},
+		{"metrics", metricsMutation(&openrtb.BidRequest_Imp_Metric{Type: proto.String("viewability")}), ""},
+		{"content data", contentDataMutation(&openrtb.BidRequest_Data{Id: proto.String("cids")}), ""},
+
+		// One case per rejection reason
+		{"nil mutation", nil, patch.ReasonInvalidPayload},
+		{"unspecified intent", idsMutation(pb.Intent_INTENT_UNSPECIFIED, pb.Operation_OPERATION_ADD, "/imp/1", "x"), patch.ReasonUnsupportedIntent},
+		{"wrong operation", idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_ADD, "/imp/1", "deal-a"), patch.ReasonUnsupportedOperation},
+		{"malformed path", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1/banner", "deal-a"), patch.ReasonInvalidPath},
+		{"path not allowed for intent", idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/imp/1", "seg-1"), patch.ReasonInvalidPath},
+		{"floor without payload", dealMutation(pb.Intent_ADJUST_DEAL_FLOOR, "/imp/1/pmp/deals/deal-a", nil), patch.ReasonPayloadMismatch},
+		{"floor with ids payload", &pb.Mutation{
+			Intent: pb.Intent_ADJUST_DEAL_FLOOR.Enum(),
+			Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+			Path:   proto.String("/imp/1/pmp/deals/deal-a"),
+			Value:  &pb.Mutation_Ids{Ids: &pb.IDsPayload{Id: []string{"deal-a"}}},
+		}, patch.ReasonPayloadMismatch},
+		{"deals with bid payload", &pb.Mutation{
+			Intent: pb.Intent_ACTIVATE_DEALS.Enum(),
+			Op:     pb.Operation_OPERATION_ADD.Enum(),
+			Path:   proto.String("/imp/1"),
+			Value:  &pb.Mutation_AdjustBid{AdjustBid: &pb.AdjustBidPayload{Price: proto.Float64(1)}},
+		}, patch.ReasonPayloadMismatch},
+		{"empty id list", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1"), patch.ReasonInvalidPayload},
+		{"floor without bidfloor", dealMutation(pb.Intent_ADJUST_DEAL_FLOOR, "/imp/1/pmp/deals/deal-a", &pb.AdjustDealPayload{Margin: margin}), patch.ReasonInvalidPayload},
+		{"margin without margin", dealMutation(pb.Intent_ADJUST_DEAL_MARGIN, "/imp/1/pmp/deals/deal-a", &pb.AdjustDealPayload{Bidfloor: proto.Float64(1)}), patch.ReasonInvalidPayload},
+		{"bid shade without price", shadeMutation(nil), patch.ReasonInvalidPayload},
+		{"empty metric list", metricsMutation(), patch.ReasonInvalidPayload},
+		{"empty data list", contentDataMutation(), patch.ReasonInvalidPayload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Mutation(tt.mutation)
+			var got patch.Reason
+			if err != nil {
+				got = err.Reason
+			}
+			if got != tt.want {
+				t.Errorf("Mutation = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	mutations := []*pb.Mutation{
+		idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", "seg-1"),
+		idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_REMOVE, "/user/data/segment", "seg-1"),
+		shadeMutation(proto.Float64(1.5)),
+		shadeMutation(nil),
+	}
+
+	valid, violations := Filter(mutations)
+	if len(valid) != 2 || valid[0] != mutations[0] || valid[1] != mutations[2] {
+		t.Errorf("Filter kept %v, want mutations 0 and 2", valid)
+	}
+	want := []struct {
+		index  int
+		reason patch.Reason
+	}{
+		{1, patch.ReasonUnsupportedOperation},
+		{3, patch.ReasonInvalidPayload},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("got %d violations, want %d", len(violations), len(want))
+	}
+	for i, w := range want {
+		v := violations[i]
+		if v.Index != w.index || v.Reason != w.reason || v.Mutation != mutations[w.index] {
+			t.Errorf("violation %d = %s, want mutation %d: %s", i, v, w.index, w.reason)
+		}
+	}
+}
+
+func TestFilterIntents(t *testing.T) {
+	mutations := []*pb.Mutation{
+		idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", "seg-1"),
+		shadeMutation(proto.Float64(1.5)),
+	}
+	valid, violations := FilterIntents(mutations, func(intent pb.Intent) bool {
+		return intent == pb.Intent_BID_SHADE
+	})
+	if len(valid) != 1 || valid[0] != mutations[1] {
+		t.Errorf("FilterIntents kept %v, want mutation 1", valid)
+	}
+	if len(violations) != 1 || violations[0].Index != 0 || violations[0].Reason != ReasonIntentNotApplicable {
+		t.Errorf("violations = %v, want mutation 0: %s", violations, ReasonIntentNotApplicable)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	_, violations := Filter([]*pb.Mutation{nil, shadeMutation(nil), metricsMutation()})
+	c := NewCounter()
+	c.Add(violations)
+	c.Add(nil)
+	if c.Total() != 3 {
+		t.Errorf("Total = %d, want 3", c.Total())
+	}
+	if got := c.Count(patch.ReasonInvalidPayload); got != 3 {
+		t.Errorf("Count(%s) = %d, want 3", patch.ReasonInvalidPayload, got)
+	}
+	if got := c.ByReason()[string(patch.ReasonInvalidPayload)]; got != 3 {
+		t.Errorf("ByReason[%s] = %d, want 3", patch.ReasonInvalidPayload, got)
+	}
+}
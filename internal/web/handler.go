@@ -26,7 +26,10 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/iabtechlab/agentic-rtb-framework/internal/audit"
 )
 
 //go:embed static/*
@@ -40,6 +43,7 @@ type Handler struct {
 	mcpEndpoint string
 	samples     map[string]Sample
 	templates   *template.Template
+	auditLog    *audit.RingBuffer
 }
 
 // Sample represents a sample ORTB payload
@@ -385,6 +389,7 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	// API routes
 	mux.HandleFunc("/api/samples", h.handleListSamples)
 	mux.HandleFunc("/api/samples/", h.handleGetSample)
+	mux.HandleFunc("/api/audit", h.handleAudit)
 
 	// Specification page
 	mux.HandleFunc("/spec", h.handleSpec)
@@ -481,6 +486,51 @@ func (h *Handler) handleGetSample(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(sample.Payload)
 }
 
+// SetAuditLog sets the in-memory audit buffer queried by /api/audit
+func (h *Handler) SetAuditLog(buffer *audit.RingBuffer) {
+	h.auditLog = buffer
+}
+
+// handleAudit returns recent mutation audit records, newest first.
+// Supports request_id, endpoint, intent, accepted and limit query parameters.
+func (h *Handler) handleAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.auditLog == nil {
+		http.Error(w, "Audit log not enabled", http.StatusNotFound)
+		return
+	}
+
+	params := r.URL.Query()
+	q := audit.Query{
+		RequestID: params.Get("request_id"),
+		Endpoint:  params.Get("endpoint"),
+		Intent:    params.Get("intent"),
+		Limit:     100,
+	}
+	if v := params.Get("accepted"); v != "" {
+		accepted, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "Invalid accepted parameter", http.StatusBadRequest)
+			return
+		}
+		q.Accepted = &accepted
+	}
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		q.Limit = limit
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.auditLog.Query(q))
+}
+
 // AddSample adds a custom sample payload
 func (h *Handler) AddSample(id string, sample Sample) {
 	h.samples[id] = sample
@@ -0,0 +1,302 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch
+
+import (
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+)
+
+// planActivateSegments adds segment IDs to /user/data/segment
+func (a *Applier) planActivateSegments(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	if _, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil); err != nil {
+		return nil, err
+	}
+	ids, err := requireIDs(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if req.User == nil {
+			req.User = &openrtb.BidRequest_User{}
+		}
+		var data *openrtb.BidRequest_Data
+		for _, d := range req.User.GetData() {
+			if d.GetId() == a.SegmentDataID {
+				data = d
+				break
+			}
+		}
+		if data == nil {
+			data = &openrtb.BidRequest_Data{
+				Id:   stringPtr(a.SegmentDataID),
+				Name: stringPtr(a.SegmentDataName),
+			}
+			req.User.Data = append(req.User.Data, data)
+		}
+		existing := make(map[string]bool)
+		for _, seg := range data.GetSegment() {
+			existing[seg.GetId()] = true
+		}
+		for _, id := range ids {
+			if !existing[id] {
+				data.Segment = append(data.Segment, &openrtb.BidRequest_Data_Segment{Id: stringPtr(id)})
+				existing[id] = true
+			}
+		}
+	}, nil
+}
+
+// planActivateDeals adds deal IDs to the pmp of /imp/{id}
+func (a *Applier) planActivateDeals(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := requireIDs(m)
+	if err != nil {
+		return nil, err
+	}
+	imp, err := findImp(req, path.ImpID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if imp.Pmp == nil {
+			imp.Pmp = &openrtb.BidRequest_Imp_Pmp{}
+		}
+		existing := make(map[string]bool)
+		for _, deal := range imp.Pmp.GetDeals() {
+			existing[deal.GetId()] = true
+		}
+		for _, id := range ids {
+			if !existing[id] {
+				imp.Pmp.Deals = append(imp.Pmp.Deals, &openrtb.BidRequest_Imp_Pmp_Deal{Id: stringPtr(id)})
+				existing[id] = true
+			}
+		}
+	}, nil
+}
+
+// planSuppressDeals removes deal IDs from the pmp of /imp/{id}
+func (a *Applier) planSuppressDeals(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := requireIDs(m)
+	if err != nil {
+		return nil, err
+	}
+	imp, err := findImp(req, path.ImpID)
+	if err != nil {
+		return nil, err
+	}
+
+	suppress := make(map[string]bool)
+	for _, id := range ids {
+		suppress[id] = true
+	}
+	found := 0
+	for _, deal := range imp.GetPmp().GetDeals() {
+		if suppress[deal.GetId()] {
+			found++
+		}
+	}
+	if found != len(suppress) {
+		return nil, reject(ReasonTargetNotFound, "not all deals to suppress exist on imp %q", imp.GetId())
+	}
+
+	return func() {
+		kept := imp.Pmp.Deals[:0]
+		for _, deal := range imp.Pmp.Deals {
+			if !suppress[deal.GetId()] {
+				kept = append(kept, deal)
+			}
+		}
+		imp.Pmp.Deals = kept
+	}, nil
+}
+
+// planAdjustDealFloor replaces the bidfloor of the deals addressed by the path
+func (a *Applier) planAdjustDealFloor(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	adjust := m.GetAdjustDeal()
+	if adjust == nil {
+		return nil, reject(ReasonPayloadMismatch, "ADJUST_DEAL_FLOOR requires AdjustDealPayload")
+	}
+	if adjust.Bidfloor == nil || adjust.GetBidfloor() < 0 {
+		return nil, reject(ReasonInvalidPayload, "bidfloor must be set and non-negative")
+	}
+	deals, err := findDeals(req, path)
+	if err != nil {
+		return nil, err
+	}
+
+	floor := adjust.GetBidfloor()
+	return func() {
+		for _, deal := range deals {
+			deal.Bidfloor = float64Ptr(floor)
+		}
+	}, nil
+}
+
+// planAdjustDealMargin applies a margin to the bidfloor of the deals addressed by the path.
+// OpenRTB has no deal margin field, so the margin is applied as a markup on the deal floor:
+// CPM margins are added to the floor, PERCENT margins scale it.
+func (a *Applier) planAdjustDealMargin(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	margin := m.GetAdjustDeal().GetMargin()
+	if margin == nil {
+		return nil, reject(ReasonPayloadMismatch, "ADJUST_DEAL_MARGIN requires AdjustDealPayload with a margin")
+	}
+	deals, err := findDeals(req, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		for _, deal := range deals {
+			floor := deal.GetBidfloor()
+			switch margin.GetCalculationType() {
+			case pb.Margin_PERCENT:
+				floor = floor * (1 + margin.GetValue()/100)
+			default:
+				floor = floor + margin.GetValue()
+			}
+			if floor < 0 {
+				floor = 0
+			}
+			deal.Bidfloor = float64Ptr(floor)
+		}
+	}, nil
+}
+
+// planBidShade replaces the price of /seatbid/{seat}/bid/{id}
+func (a *Applier) planBidShade(resp *openrtb.BidResponse, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), nil, resp)
+	if err != nil {
+		return nil, err
+	}
+	adjust := m.GetAdjustBid()
+	if adjust == nil {
+		return nil, reject(ReasonPayloadMismatch, "BID_SHADE requires AdjustBidPayload")
+	}
+	if adjust.Price == nil || adjust.GetPrice() <= 0 {
+		return nil, reject(ReasonInvalidPayload, "price must be set and positive")
+	}
+	bid, err := findBid(resp, path.Seat, path.BidID)
+	if err != nil {
+		return nil, err
+	}
+
+	price := adjust.GetPrice()
+	return func() {
+		bid.Price = float64Ptr(price)
+	}, nil
+}
+
+// planAddMetrics appends metrics to /imp/{id}/metric
+func (a *Applier) planAddMetrics(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	metrics := m.GetMetrics()
+	if metrics == nil {
+		return nil, reject(ReasonPayloadMismatch, "ADD_METRICS requires MetricsPayload")
+	}
+	if len(metrics.GetMetric()) == 0 {
+		return nil, reject(ReasonInvalidPayload, "metric list is empty")
+	}
+	for _, metric := range metrics.GetMetric() {
+		if metric.GetType() == "" {
+			return nil, reject(ReasonInvalidPayload, "metric type is required")
+		}
+	}
+	imp, err := findImp(req, path.ImpID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		imp.Metric = append(imp.Metric, metrics.GetMetric()...)
+	}, nil
+}
+
+// planAddContentData appends content data to /site/content/data or /app/content/data
+func (a *Applier) planAddContentData(req *openrtb.BidRequest, m *pb.Mutation) (func(), *RejectionError) {
+	path, err := ValidatePath(m.GetIntent(), m.GetOp(), m.GetPath(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	payload := m.GetContentData()
+	if payload == nil {
+		return nil, reject(ReasonPayloadMismatch, "ADD_CIDS requires DataPayload")
+	}
+	if len(payload.GetData()) == 0 {
+		return nil, reject(ReasonInvalidPayload, "data list is empty")
+	}
+
+	var content **openrtb.BidRequest_Content
+	if path.Kind == PathSiteContentData {
+		content = &req.GetSite().Content
+	} else {
+		content = &req.GetApp().Content
+	}
+
+	return func() {
+		if *content == nil {
+			*content = &openrtb.BidRequest_Content{}
+		}
+		(*content).Data = append((*content).Data, payload.GetData()...)
+	}, nil
+}
+
+// requireIDs returns the non-empty IDs of an IDsPayload
+func requireIDs(m *pb.Mutation) ([]string, *RejectionError) {
+	payload := m.GetIds()
+	if payload == nil {
+		return nil, reject(ReasonPayloadMismatch, "%v requires IDsPayload", m.GetIntent())
+	}
+	if len(payload.GetId()) == 0 {
+		return nil, reject(ReasonInvalidPayload, "ID list is empty")
+	}
+	for _, id := range payload.GetId() {
+		if id == "" {
+			return nil, reject(ReasonInvalidPayload, "ID list contains an empty ID")
+		}
+	}
+	return payload.GetId(), nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
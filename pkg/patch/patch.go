@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package patch applies ARTF mutations to OpenRTB bid requests and bid responses.
+// It implements the OpenRTB Patch semantics from the ARTF specification: each
+// mutation is evaluated independently and is either applied in full or rejected
+// with a reason, leaving the payload untouched.
+package patch
+
+import (
+	"fmt"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+)
+
+// Reason classifies why a mutation was rejected
+type Reason string
+
+const (
+	// ReasonUnsupportedIntent means the intent has no patch semantics
+	ReasonUnsupportedIntent Reason = "unsupported_intent"
+
+	// ReasonUnsupportedOperation means the operation is not allowed for the intent
+	ReasonUnsupportedOperation Reason = "unsupported_operation"
+
+	// ReasonInvalidPath means the path is malformed or not allowed for the intent
+	ReasonInvalidPath Reason = "invalid_path"
+
+	// ReasonPayloadMismatch means the payload type does not match the intent
+	ReasonPayloadMismatch Reason = "payload_mismatch"
+
+	// ReasonInvalidPayload means the payload has the right type but unusable values
+	ReasonInvalidPayload Reason = "invalid_payload"
+
+	// ReasonTargetNotFound means the imp, deal, bid or object referenced by the path does not exist
+	ReasonTargetNotFound Reason = "target_not_found"
+
+	// ReasonMissingPayload means the bid request or bid response the intent applies to was not provided
+	ReasonMissingPayload Reason = "missing_payload"
+)
+
+// RejectionError is returned when a mutation cannot be applied
+type RejectionError struct {
+	Reason Reason
+	Detail string
+}
+
+// Error implements the error interface
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Detail)
+}
+
+// reject builds a RejectionError with a formatted detail message
+func reject(reason Reason, format string, args ...interface{}) *RejectionError {
+	return &RejectionError{Reason: reason, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Result is the outcome of applying a single mutation
+type Result struct {
+	// Index is the position of the mutation in the input slice
+	Index int `json:"index"`
+
+	// Mutation is the mutation that was evaluated
+	Mutation *pb.Mutation `json:"mutation"`
+
+	// Accepted is true if the mutation was applied
+	Accepted bool `json:"accepted"`
+
+	// Reason classifies the rejection (empty if accepted)
+	Reason Reason `json:"reason,omitempty"`
+
+	// Detail is a human-readable explanation of the rejection
+	Detail string `json:"detail,omitempty"`
+}
+
+// Applier applies mutations to OpenRTB payloads
+type Applier struct {
+	// SegmentDataID is the user.data id that activated segments are added under
+	SegmentDataID string
+
+	// SegmentDataName is the user.data name used when the data object is created
+	SegmentDataName string
+}
+
+// NewApplier creates an applier with default settings
+func NewApplier() *Applier {
+	return &Applier{
+		SegmentDataID:   "artf",
+		SegmentDataName: "ARTF Activated Segments",
+	}
+}
+
+// Apply applies each mutation in order to the bid request and bid response.
+// Each mutation is accepted or rejected atomically; a rejected mutation leaves
+// both payloads unchanged and does not affect later mutations.
+func (a *Applier) Apply(req *openrtb.BidRequest, resp *openrtb.BidResponse, mutations []*pb.Mutation) []Result {
+	results := make([]Result, 0, len(mutations))
+	for i, m := range mutations {
+		result := Result{Index: i, Mutation: m}
+		if err := a.ApplyMutation(req, resp, m); err != nil {
+			result.Reason = err.Reason
+			result.Detail = err.Detail
+		} else {
+			result.Accepted = true
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// ApplyMutation applies a single mutation. It either applies the full change
+// or returns a RejectionError without modifying either payload.
+func (a *Applier) ApplyMutation(req *openrtb.BidRequest, resp *openrtb.BidResponse, m *pb.Mutation) *RejectionError {
+	commit, err := a.plan(req, resp, m)
+	if err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+// plan validates a mutation and returns a commit function that performs the change.
+// Commit functions never fail, which keeps each mutation atomic.
+func (a *Applier) plan(req *openrtb.BidRequest, resp *openrtb.BidResponse, m *pb.Mutation) (func(), *RejectionError) {
+	if m == nil {
+		return nil, reject(ReasonInvalidPayload, "mutation is nil")
+	}
+
+	switch m.GetIntent() {
+	case pb.Intent_ACTIVATE_SEGMENTS:
+		return a.planActivateSegments(req, m)
+	case pb.Intent_ACTIVATE_DEALS:
+		return a.planActivateDeals(req, m)
+	case pb.Intent_SUPPRESS_DEALS:
+		return a.planSuppressDeals(req, m)
+	case pb.Intent_ADJUST_DEAL_FLOOR:
+		return a.planAdjustDealFloor(req, m)
+	case pb.Intent_ADJUST_DEAL_MARGIN:
+		return a.planAdjustDealMargin(req, m)
+	case pb.Intent_BID_SHADE:
+		return a.planBidShade(resp, m)
+	case pb.Intent_ADD_METRICS:
+		return a.planAddMetrics(req, m)
+	case pb.Intent_ADD_CIDS:
+		return a.planAddContentData(req, m)
+	default:
+		return nil, reject(ReasonUnsupportedIntent, "intent %v has no patch semantics", m.GetIntent())
+	}
+}
+
+// Accepted returns the mutations from results that were applied
+func Accepted(results []Result) []*pb.Mutation {
+	var accepted []*pb.Mutation
+	for _, r := range results {
+		if r.Accepted {
+			accepted = append(accepted, r.Mutation)
+		}
+	}
+	return accepted
+}
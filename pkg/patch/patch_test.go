@@ -0,0 +1,391 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch
+
+import (
+	"math"
+	"testing"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+	"google.golang.org/protobuf/proto"
+)
+
+// testRequest returns a site bid request with two imps; imp "a/b~c" checks
+// that escaped path tokens resolve to the raw id
+func testRequest() *openrtb.BidRequest {
+	return &openrtb.BidRequest{
+		Id: proto.String("req-1"),
+		Imp: []*openrtb.BidRequest_Imp{
+			{
+				Id: proto.String("1"),
+				Pmp: &openrtb.BidRequest_Imp_Pmp{Deals: []*openrtb.BidRequest_Imp_Pmp_Deal{
+					{Id: proto.String("deal-a"), Bidfloor: proto.Float64(1)},
+					{Id: proto.String("deal-b"), Bidfloor: proto.Float64(2)},
+				}},
+			},
+			{Id: proto.String("a/b~c")},
+		},
+		DistributionchannelOneof: &openrtb.BidRequest_Site_{Site: &openrtb.BidRequest_Site{Id: proto.String("site-1")}},
+	}
+}
+
+// testResponse returns a bid response with a single bid
+func testResponse() *openrtb.BidResponse {
+	return &openrtb.BidResponse{
+		Id: proto.String("req-1"),
+		Seatbid: []*openrtb.BidResponse_SeatBid{{
+			Seat: proto.String("seat-1"),
+			Bid: []*openrtb.BidResponse_SeatBid_Bid{
+				{Id: proto.String("bid-1"), Impid: proto.String("1"), Price: proto.Float64(2.5)},
+			},
+		}},
+	}
+}
+
+func idsMutation(intent pb.Intent, op pb.Operation, path string, ids ...string) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: intent.Enum(),
+		Op:     op.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_Ids{Ids: &pb.IDsPayload{Id: ids}},
+	}
+}
+
+func floorMutation(path string, floor float64) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADJUST_DEAL_FLOOR.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{Bidfloor: proto.Float64(floor)}},
+	}
+}
+
+func marginMutation(path string, value float64, calc pb.Margin_CalculationType) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADJUST_DEAL_MARGIN.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value: &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{
+			Margin: &pb.Margin{Value: proto.Float64(value), CalculationType: calc.Enum()},
+		}},
+	}
+}
+
+func shadeMutation(path string, price float64) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_BID_SHADE.Enum(),
+		Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_AdjustBid{AdjustBid: &pb.AdjustBidPayload{Price: proto.Float64(price)}},
+	}
+}
+
+func metricsMutation(path string, metrics ...*openrtb.BidRequest_Imp_Metric) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADD_METRICS.Enum(),
+		Op:     pb.Operation_OPERATION_ADD.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_Metrics{Metrics: &pb.MetricsPayload{Metric: metrics}},
+	}
+}
+
+func contentDataMutation(path string, data ...*openrtb.BidRequest_Data) *pb.Mutation {
+	return &pb.Mutation{
+		Intent: pb.Intent_ADD_CIDS.Enum(),
+		Op:     pb.Operation_OPERATION_ADD.Enum(),
+		Path:   proto.String(path),
+		Value:  &pb.Mutation_ContentData{ContentData: &pb.DataPayload{Data: data}},
+	}
+}
+
+// dealFloors returns the bidfloor of each deal on an imp by deal id
+func dealFloors(req *openrtb.BidRequest, impID string) map[string]float64 {
+	floors := make(map[string]float64)
+	imp, _ := findImp(req, impID)
+	for _, deal := range imp.GetPmp().GetDeals() {
+		floors[deal.GetId()] = deal.GetBidfloor()
+	}
+	return floors
+}
+
+func equalFloors(got, want map[string]float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for id, floor := range want {
+		if f, ok := got[id]; !ok || math.Abs(f-floor) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestApplyIntents(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutation *pb.Mutation
+		check    func(t *testing.T, req *openrtb.BidRequest, resp *openrtb.BidResponse)
+	}{
+		{
+			name:     "activate segments",
+			mutation: idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", "seg-1", "seg-2", "seg-1"),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				data := req.GetUser().GetData()
+				if len(data) != 1 || data[0].GetId() != "artf" {
+					t.Fatalf("user.data = %v, want one artf data object", data)
+				}
+				var ids []string
+				for _, seg := range data[0].GetSegment() {
+					ids = append(ids, seg.GetId())
+				}
+				if len(ids) != 2 || ids[0] != "seg-1" || ids[1] != "seg-2" {
+					t.Errorf("segments = %v, want [seg-1 seg-2]", ids)
+				}
+			},
+		},
+		{
+			name:     "activate deals",
+			mutation: idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1/pmp/deals", "deal-c", "deal-a"),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 1, "deal-b": 2, "deal-c": 0}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "activate deals on escaped imp id",
+			mutation: idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/a~1b~0c", "deal-c"),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-c": 0}
+				if got := dealFloors(req, "a/b~c"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "suppress deals",
+			mutation: idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", "deal-a"),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-b": 2}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "adjust deal floor",
+			mutation: floorMutation("/imp/1/pmp/deals/deal-b", 3.5),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 1, "deal-b": 3.5}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "adjust floor of every deal",
+			mutation: floorMutation("/imp/1/pmp/deals", 4),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 4, "deal-b": 4}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "adjust deal margin by cpm",
+			mutation: marginMutation("/imp/1/deals/deal-a", 0.5, pb.Margin_CPM),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 1.5, "deal-b": 2}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "adjust deal margin by percent",
+			mutation: marginMutation("/imp/1/pmp/deals", 10, pb.Margin_PERCENT),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 1.1, "deal-b": 2.2}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "negative margin floors at zero",
+			mutation: marginMutation("/imp/1/pmp/deals/deal-a", -5, pb.Margin_CPM),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				want := map[string]float64{"deal-a": 0, "deal-b": 2}
+				if got := dealFloors(req, "1"); !equalFloors(got, want) {
+					t.Errorf("deals = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:     "bid shade",
+			mutation: shadeMutation("/seatbid/seat-1/bid/bid-1", 2),
+			check: func(t *testing.T, _ *openrtb.BidRequest, resp *openrtb.BidResponse) {
+				if got := resp.GetSeatbid()[0].GetBid()[0].GetPrice(); got != 2 {
+					t.Errorf("price = %v, want 2", got)
+				}
+			},
+		},
+		{
+			name: "add metrics",
+			mutation: metricsMutation("/imp/1/metric",
+				&openrtb.BidRequest_Imp_Metric{Type: proto.String("viewability"), Value: proto.Float64(0.8)}),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				metrics := req.GetImp()[0].GetMetric()
+				if len(metrics) != 1 || metrics[0].GetType() != "viewability" {
+					t.Errorf("metrics = %v, want one viewability metric", metrics)
+				}
+			},
+		},
+		{
+			name:     "add content data",
+			mutation: contentDataMutation("/site/content/data", &openrtb.BidRequest_Data{Id: proto.String("cids")}),
+			check: func(t *testing.T, req *openrtb.BidRequest, _ *openrtb.BidResponse) {
+				data := req.GetSite().GetContent().GetData()
+				if len(data) != 1 || data[0].GetId() != "cids" {
+					t.Errorf("site.content.data = %v, want one cids data object", data)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, resp := testRequest(), testResponse()
+			if err := NewApplier().ApplyMutation(req, resp, tt.mutation); err != nil {
+				t.Fatalf("ApplyMutation: %v", err)
+			}
+			tt.check(t, req, resp)
+		})
+	}
+}
+
+func TestApplyRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutation *pb.Mutation
+		noResp   bool
+		want     Reason
+	}{
+		{"nil mutation", nil, false, ReasonInvalidPayload},
+		{"unspecified intent", idsMutation(pb.Intent_INTENT_UNSPECIFIED, pb.Operation_OPERATION_ADD, "/imp/1", "x"), false, ReasonUnsupportedIntent},
+		{"wrong operation", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", "x"), false, ReasonUnsupportedOperation},
+		{"relative path", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "imp/1", "x"), false, ReasonInvalidPath},
+		{"unknown path", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1/banner", "x"), false, ReasonInvalidPath},
+		{"path not allowed for intent", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/user/data/segment", "x"), false, ReasonInvalidPath},
+		{"empty imp id", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp//pmp/deals", "x"), false, ReasonInvalidPath},
+		{"deal path for bid shade", shadeMutation("/imp/1/pmp/deals/deal-a", 1), false, ReasonInvalidPath},
+		{"ids payload mismatch", &pb.Mutation{
+			Intent: pb.Intent_ACTIVATE_DEALS.Enum(),
+			Op:     pb.Operation_OPERATION_ADD.Enum(),
+			Path:   proto.String("/imp/1"),
+			Value:  &pb.Mutation_AdjustBid{AdjustBid: &pb.AdjustBidPayload{Price: proto.Float64(1)}},
+		}, false, ReasonPayloadMismatch},
+		{"empty id list", idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment"), false, ReasonInvalidPayload},
+		{"empty id", idsMutation(pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", "seg-1", ""), false, ReasonInvalidPayload},
+		{"negative floor", floorMutation("/imp/1/pmp/deals/deal-a", -1), false, ReasonInvalidPayload},
+		{"margin without margin", &pb.Mutation{
+			Intent: pb.Intent_ADJUST_DEAL_MARGIN.Enum(),
+			Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+			Path:   proto.String("/imp/1/pmp/deals/deal-a"),
+			Value:  &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{Bidfloor: proto.Float64(1)}},
+		}, false, ReasonPayloadMismatch},
+		{"floor without floor", &pb.Mutation{
+			Intent: pb.Intent_ADJUST_DEAL_FLOOR.Enum(),
+			Op:     pb.Operation_OPERATION_REPLACE.Enum(),
+			Path:   proto.String("/imp/1/pmp/deals/deal-a"),
+			Value:  &pb.Mutation_AdjustDeal{AdjustDeal: &pb.AdjustDealPayload{Margin: &pb.Margin{Value: proto.Float64(1)}}},
+		}, false, ReasonInvalidPayload},
+		{"zero bid price", shadeMutation("/seatbid/seat-1/bid/bid-1", 0), false, ReasonInvalidPayload},
+		{"empty metrics", metricsMutation("/imp/1/metric"), false, ReasonInvalidPayload},
+		{"metric without type", metricsMutation("/imp/1/metric", &openrtb.BidRequest_Imp_Metric{Value: proto.Float64(1)}), false, ReasonInvalidPayload},
+		{"empty content data", contentDataMutation("/site/content/data"), false, ReasonInvalidPayload},
+		{"imp not found", idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/9", "x"), false, ReasonTargetNotFound},
+		{"deal not found", floorMutation("/imp/1/pmp/deals/deal-z", 1), false, ReasonTargetNotFound},
+		{"imp without deals", floorMutation("/imp/a~1b~0c/pmp/deals", 1), false, ReasonTargetNotFound},
+		{"deal to suppress not found", idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1", "deal-a", "deal-z"), false, ReasonTargetNotFound},
+		{"bid not found", shadeMutation("/seatbid/seat-1/bid/bid-9", 1), false, ReasonTargetNotFound},
+		{"seat not found", shadeMutation("/seatbid/seat-2/bid/bid-1", 1), false, ReasonTargetNotFound},
+		{"app content on site request", contentDataMutation("/app/content/data", &openrtb.BidRequest_Data{Id: proto.String("cids")}), false, ReasonTargetNotFound},
+		{"missing bid response", shadeMutation("/seatbid/seat-1/bid/bid-1", 1), true, ReasonMissingPayload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, resp := testRequest(), testResponse()
+			if tt.noResp {
+				resp = nil
+			}
+			err := NewApplier().ApplyMutation(req, resp, tt.mutation)
+			if err == nil {
+				t.Fatalf("ApplyMutation accepted the mutation, want %s", tt.want)
+			}
+			if err.Reason != tt.want {
+				t.Errorf("reason = %s (%s), want %s", err.Reason, err.Detail, tt.want)
+			}
+			if !proto.Equal(req, testRequest()) {
+				t.Error("rejected mutation modified the bid request")
+			}
+			if resp != nil && !proto.Equal(resp, testResponse()) {
+				t.Error("rejected mutation modified the bid response")
+			}
+		})
+	}
+}
+
+func TestApplyBatch(t *testing.T) {
+	req, resp := testRequest(), testResponse()
+	mutations := []*pb.Mutation{
+		idsMutation(pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1/pmp/deals", "deal-c"),
+		// deal-z does not exist, so deal-a must not be suppressed either
+		idsMutation(pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1/pmp/deals", "deal-a", "deal-z"),
+		floorMutation("/imp/1/pmp/deals/deal-a", 5),
+		// Later mutations see earlier ones: deal-c was activated above
+		floorMutation("/imp/1/pmp/deals/deal-c", 3),
+	}
+
+	results := NewApplier().Apply(req, resp, mutations)
+	wantAccepted := []bool{true, false, true, true}
+	if len(results) != len(wantAccepted) {
+		t.Fatalf("got %d results, want %d", len(results), len(wantAccepted))
+	}
+	for i, r := range results {
+		if r.Index != i || r.Mutation != mutations[i] {
+			t.Errorf("result %d is for mutation %d", i, r.Index)
+		}
+		if r.Accepted != wantAccepted[i] {
+			t.Errorf("result %d accepted = %v, want %v (%s: %s)", i, r.Accepted, wantAccepted[i], r.Reason, r.Detail)
+		}
+	}
+	if results[1].Reason != ReasonTargetNotFound {
+		t.Errorf("result 1 reason = %s, want %s", results[1].Reason, ReasonTargetNotFound)
+	}
+
+	want := map[string]float64{"deal-a": 5, "deal-b": 2, "deal-c": 3}
+	if got := dealFloors(req, "1"); !equalFloors(got, want) {
+		t.Errorf("deals = %v, want %v", got, want)
+	}
+	if accepted := Accepted(results); len(accepted) != 3 || accepted[1] != mutations[2] {
+		t.Errorf("Accepted returned %d mutations, want mutations 0, 2 and 3", len(accepted))
+	}
+}
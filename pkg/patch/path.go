@@ -0,0 +1,350 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+	openrtb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/openrtb"
+)
+
+// PathKind identifies the business entity a mutation path refers to
+type PathKind int
+
+const (
+	// PathUnknown is the zero value for an unparsed path
+	PathUnknown PathKind = iota
+
+	// PathUserSegments is /user/data/segment
+	PathUserSegments
+
+	// PathImp is /imp/{id}
+	PathImp
+
+	// PathImpDeals is /imp/{id}/pmp/deals and addresses every deal on the impression
+	PathImpDeals
+
+	// PathDeal is /imp/{id}/pmp/deals/{dealid}
+	PathDeal
+
+	// PathImpMetric is /imp/{id}/metric
+	PathImpMetric
+
+	// PathBid is /seatbid/{seat}/bid/{bidid}
+	PathBid
+
+	// PathSiteContentData is /site/content/data
+	PathSiteContentData
+
+	// PathAppContentData is /app/content/data
+	PathAppContentData
+)
+
+// String returns the path template for the kind
+func (k PathKind) String() string {
+	switch k {
+	case PathUserSegments:
+		return "/user/data/segment"
+	case PathImp:
+		return "/imp/{id}"
+	case PathImpDeals:
+		return "/imp/{id}/pmp/deals"
+	case PathDeal:
+		return "/imp/{id}/pmp/deals/{dealid}"
+	case PathImpMetric:
+		return "/imp/{id}/metric"
+	case PathBid:
+		return "/seatbid/{seat}/bid/{bidid}"
+	case PathSiteContentData:
+		return "/site/content/data"
+	case PathAppContentData:
+		return "/app/content/data"
+	default:
+		return "unknown"
+	}
+}
+
+// Path is a parsed semantic mutation path.
+// Identifiers are stored unescaped; String escapes them per RFC 6901.
+type Path struct {
+	Kind   PathKind
+	ImpID  string
+	DealID string
+	Seat   string
+	BidID  string
+}
+
+// UserSegmentsPath returns /user/data/segment
+func UserSegmentsPath() Path {
+	return Path{Kind: PathUserSegments}
+}
+
+// ImpPath returns /imp/{impID}
+func ImpPath(impID string) Path {
+	return Path{Kind: PathImp, ImpID: impID}
+}
+
+// ImpDealsPath returns /imp/{impID}/pmp/deals
+func ImpDealsPath(impID string) Path {
+	return Path{Kind: PathImpDeals, ImpID: impID}
+}
+
+// DealPath returns /imp/{impID}/pmp/deals/{dealID}
+func DealPath(impID, dealID string) Path {
+	return Path{Kind: PathDeal, ImpID: impID, DealID: dealID}
+}
+
+// ImpMetricPath returns /imp/{impID}/metric
+func ImpMetricPath(impID string) Path {
+	return Path{Kind: PathImpMetric, ImpID: impID}
+}
+
+// BidPath returns /seatbid/{seat}/bid/{bidID}
+func BidPath(seat, bidID string) Path {
+	return Path{Kind: PathBid, Seat: seat, BidID: bidID}
+}
+
+// SiteContentDataPath returns /site/content/data
+func SiteContentDataPath() Path {
+	return Path{Kind: PathSiteContentData}
+}
+
+// AppContentDataPath returns /app/content/data
+func AppContentDataPath() Path {
+	return Path{Kind: PathAppContentData}
+}
+
+// String renders the path in its canonical form
+func (p Path) String() string {
+	switch p.Kind {
+	case PathUserSegments, PathSiteContentData, PathAppContentData:
+		return p.Kind.String()
+	case PathImp:
+		return "/imp/" + escape(p.ImpID)
+	case PathImpDeals:
+		return "/imp/" + escape(p.ImpID) + "/pmp/deals"
+	case PathDeal:
+		return "/imp/" + escape(p.ImpID) + "/pmp/deals/" + escape(p.DealID)
+	case PathImpMetric:
+		return "/imp/" + escape(p.ImpID) + "/metric"
+	case PathBid:
+		return "/seatbid/" + escape(p.Seat) + "/bid/" + escape(p.BidID)
+	default:
+		return ""
+	}
+}
+
+// ParsePath parses a mutation path into its typed form.
+// The spec shorthand /imp/{id}/deals/{dealid} is accepted as a deal path.
+// An empty seat is allowed in bid paths because seatbid.seat is optional.
+func ParsePath(s string) (Path, error) {
+	if !strings.HasPrefix(s, "/") {
+		return Path{}, fmt.Errorf("path %q must start with '/'", s)
+	}
+	raw := strings.Split(s[1:], "/")
+	segments := make([]string, len(raw))
+	for i, seg := range raw {
+		segments[i] = unescape(seg)
+	}
+
+	switch {
+	case s == "/user/data/segment":
+		return UserSegmentsPath(), nil
+	case s == "/site/content/data":
+		return SiteContentDataPath(), nil
+	case s == "/app/content/data":
+		return AppContentDataPath(), nil
+	case len(segments) >= 2 && raw[0] == "imp":
+		if segments[1] == "" {
+			return Path{}, fmt.Errorf("path %q has an empty imp id", s)
+		}
+		return parseImpPath(s, raw, segments)
+	case len(segments) == 4 && raw[0] == "seatbid" && raw[2] == "bid":
+		if segments[3] == "" {
+			return Path{}, fmt.Errorf("path %q has an empty bid id", s)
+		}
+		return BidPath(segments[1], segments[3]), nil
+	}
+	return Path{}, fmt.Errorf("path %q does not match any known path", s)
+}
+
+// parseImpPath parses the /imp/{id}/... family of paths
+func parseImpPath(s string, raw, segments []string) (Path, error) {
+	impID := segments[1]
+	switch {
+	case len(raw) == 2:
+		return ImpPath(impID), nil
+	case len(raw) == 3 && raw[2] == "metric":
+		return ImpMetricPath(impID), nil
+	case len(raw) == 4 && raw[2] == "pmp" && raw[3] == "deals":
+		return ImpDealsPath(impID), nil
+	case len(raw) == 5 && raw[2] == "pmp" && raw[3] == "deals" && segments[4] != "":
+		return DealPath(impID, segments[4]), nil
+	case len(raw) == 4 && raw[2] == "deals" && segments[3] != "":
+		return DealPath(impID, segments[3]), nil
+	}
+	return Path{}, fmt.Errorf("path %q does not match any known imp path", s)
+}
+
+// pathRule lists the operation and path kinds that are legal for an intent
+type pathRule struct {
+	op    pb.Operation
+	kinds []PathKind
+}
+
+// pathRules maps each intent to its legal operation and path kinds
+var pathRules = map[pb.Intent]pathRule{
+	pb.Intent_ACTIVATE_SEGMENTS:  {pb.Operation_OPERATION_ADD, []PathKind{PathUserSegments}},
+	pb.Intent_ACTIVATE_DEALS:     {pb.Operation_OPERATION_ADD, []PathKind{PathImp, PathImpDeals}},
+	pb.Intent_SUPPRESS_DEALS:     {pb.Operation_OPERATION_REMOVE, []PathKind{PathImp, PathImpDeals}},
+	pb.Intent_ADJUST_DEAL_FLOOR:  {pb.Operation_OPERATION_REPLACE, []PathKind{PathDeal, PathImpDeals}},
+	pb.Intent_ADJUST_DEAL_MARGIN: {pb.Operation_OPERATION_REPLACE, []PathKind{PathDeal, PathImpDeals}},
+	pb.Intent_BID_SHADE:          {pb.Operation_OPERATION_REPLACE, []PathKind{PathBid}},
+	pb.Intent_ADD_METRICS:        {pb.Operation_OPERATION_ADD, []PathKind{PathImpMetric, PathImp}},
+	pb.Intent_ADD_CIDS:           {pb.Operation_OPERATION_ADD, []PathKind{PathSiteContentData, PathAppContentData}},
+}
+
+// CheckPath parses a path and checks that it is legal for the given intent and
+// operation, without resolving it against a bid request or bid response.
+func CheckPath(intent pb.Intent, op pb.Operation, path string) (Path, *RejectionError) {
+	rule, ok := pathRules[intent]
+	if !ok {
+		return Path{}, reject(ReasonUnsupportedIntent, "intent %v has no patch semantics", intent)
+	}
+	if op != rule.op {
+		return Path{}, reject(ReasonUnsupportedOperation, "%v requires %v, got %v", intent, rule.op, op)
+	}
+
+	p, err := ParsePath(path)
+	if err != nil {
+		return Path{}, reject(ReasonInvalidPath, "%v", err)
+	}
+	allowed := false
+	for _, kind := range rule.kinds {
+		if p.Kind == kind {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return Path{}, reject(ReasonInvalidPath, "%v does not allow path %s", intent, p.Kind)
+	}
+	return p, nil
+}
+
+// ValidatePath parses a path and checks that it is legal for the given intent
+// and operation, and that the imp, deal, bid or object it references exists.
+// BID_SHADE paths are resolved against resp; all other paths against req.
+func ValidatePath(intent pb.Intent, op pb.Operation, path string, req *openrtb.BidRequest, resp *openrtb.BidResponse) (Path, *RejectionError) {
+	p, err := CheckPath(intent, op, path)
+	if err != nil {
+		return Path{}, err
+	}
+
+	if p.Kind == PathBid {
+		if resp == nil {
+			return Path{}, reject(ReasonMissingPayload, "bid response is required")
+		}
+		if _, err := findBid(resp, p.Seat, p.BidID); err != nil {
+			return Path{}, err
+		}
+		return p, nil
+	}
+
+	if req == nil {
+		return Path{}, reject(ReasonMissingPayload, "bid request is required")
+	}
+	switch p.Kind {
+	case PathImp, PathImpDeals, PathImpMetric:
+		if _, err := findImp(req, p.ImpID); err != nil {
+			return Path{}, err
+		}
+	case PathDeal:
+		if _, err := findDeals(req, p); err != nil {
+			return Path{}, err
+		}
+	case PathSiteContentData:
+		if req.GetSite() == nil {
+			return Path{}, reject(ReasonTargetNotFound, "bid request has no site object")
+		}
+	case PathAppContentData:
+		if req.GetApp() == nil {
+			return Path{}, reject(ReasonTargetNotFound, "bid request has no app object")
+		}
+	}
+	return p, nil
+}
+
+// findImp returns the impression with the given id
+func findImp(req *openrtb.BidRequest, impID string) (*openrtb.BidRequest_Imp, *RejectionError) {
+	for _, imp := range req.GetImp() {
+		if imp.GetId() == impID {
+			return imp, nil
+		}
+	}
+	return nil, reject(ReasonTargetNotFound, "imp %q not found", impID)
+}
+
+// findDeals returns the deals addressed by a deal or imp-deals path
+func findDeals(req *openrtb.BidRequest, p Path) ([]*openrtb.BidRequest_Imp_Pmp_Deal, *RejectionError) {
+	imp, err := findImp(req, p.ImpID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deals []*openrtb.BidRequest_Imp_Pmp_Deal
+	for _, deal := range imp.GetPmp().GetDeals() {
+		if p.Kind == PathImpDeals || deal.GetId() == p.DealID {
+			deals = append(deals, deal)
+		}
+	}
+	if len(deals) == 0 {
+		if p.Kind == PathImpDeals {
+			return nil, reject(ReasonTargetNotFound, "imp %q has no deals", p.ImpID)
+		}
+		return nil, reject(ReasonTargetNotFound, "deal %q not found on imp %q", p.DealID, p.ImpID)
+	}
+	return deals, nil
+}
+
+// findBid returns the bid with the given id in the given seat
+func findBid(resp *openrtb.BidResponse, seat, bidID string) (*openrtb.BidResponse_SeatBid_Bid, *RejectionError) {
+	for _, sb := range resp.GetSeatbid() {
+		if sb.GetSeat() != seat {
+			continue
+		}
+		for _, bid := range sb.GetBid() {
+			if bid.GetId() == bidID {
+				return bid, nil
+			}
+		}
+	}
+	return nil, reject(ReasonTargetNotFound, "bid %q not found in seat %q", bidID, seat)
+}
+
+// escape encodes an identifier as an RFC 6901 reference token
+func escape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// unescape decodes an RFC 6901 reference token
+func unescape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Index Exchange Inc.
+//
+// This file is part of the Agentic RTB Framework reference implementation.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch
+
+import (
+	"testing"
+
+	pb "github.com/iabtechlab/agentic-rtb-framework/pkg/pb/artf"
+)
+
+func TestPathRoundTrip(t *testing.T) {
+	tests := []struct {
+		path Path
+		want string
+	}{
+		{UserSegmentsPath(), "/user/data/segment"},
+		{ImpPath("1"), "/imp/1"},
+		{ImpDealsPath("1"), "/imp/1/pmp/deals"},
+		{DealPath("1", "deal-a"), "/imp/1/pmp/deals/deal-a"},
+		{ImpMetricPath("1"), "/imp/1/metric"},
+		{BidPath("seat-1", "bid-1"), "/seatbid/seat-1/bid/bid-1"},
+		{BidPath("", "bid-1"), "/seatbid//bid/bid-1"},
+		{SiteContentDataPath(), "/site/content/data"},
+		{AppContentDataPath(), "/app/content/data"},
+		{ImpPath("a/b"), "/imp/a~1b"},
+		{DealPath("~1", "x/~y"), "/imp/~01/pmp/deals/x~1~0y"},
+		{BidPath("a/b", "~"), "/seatbid/a~1b/bid/~0"},
+	}
+	for _, tt := range tests {
+		s := tt.path.String()
+		if s != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.path, s, tt.want)
+			continue
+		}
+		got, err := ParsePath(s)
+		if err != nil {
+			t.Errorf("ParsePath(%q): %v", s, err)
+			continue
+		}
+		if got != tt.path {
+			t.Errorf("ParsePath(%q) = %#v, want %#v", s, got, tt.path)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    Path
+		wantErr bool
+	}{
+		// The spec shorthand for a deal
+		{path: "/imp/1/deals/deal-a", want: DealPath("1", "deal-a")},
+		// ~01 decodes to ~1, not /
+		{path: "/imp/~01", want: ImpPath("~1")},
+		{path: "/imp/a~1b/metric", want: ImpMetricPath("a/b")},
+
+		// Unknown roots
+		{path: "/device/ua", wantErr: true},
+		{path: "/user/data", wantErr: true},
+		{path: "/site/content", wantErr: true},
+		{path: "/", wantErr: true},
+		{path: "", wantErr: true},
+		{path: "imp/1", wantErr: true},
+
+		// Wrong segment counts
+		{path: "/imp", wantErr: true},
+		{path: "/imp/", wantErr: true},
+		{path: "/imp/1/pmp", wantErr: true},
+		{path: "/imp/1/pmp/deals/", wantErr: true},
+		{path: "/imp/1/pmp/deals/deal-a/bidfloor", wantErr: true},
+		{path: "/imp/1/deals/", wantErr: true},
+		{path: "/imp/1/metric/0", wantErr: true},
+		{path: "/seatbid/seat-1/bid", wantErr: true},
+		{path: "/seatbid/seat-1/bid/", wantErr: true},
+		{path: "/seatbid/seat-1/bid/bid-1/price", wantErr: true},
+		{path: "/user/data/segment/1", wantErr: true},
+
+		// Escaped structural segments are identifiers, not keywords
+		{path: "/imp/1/p~1mp/deals", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.path)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePath(%q) = %#v, want error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePath(%q): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePath(%q) = %#v, want %#v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	tests := []struct {
+		intent pb.Intent
+		op     pb.Operation
+		path   string
+		want   Reason
+	}{
+		{pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_ADD, "/user/data/segment", ""},
+		{pb.Intent_ACTIVATE_DEALS, pb.Operation_OPERATION_ADD, "/imp/1", ""},
+		{pb.Intent_SUPPRESS_DEALS, pb.Operation_OPERATION_REMOVE, "/imp/1/pmp/deals", ""},
+		{pb.Intent_ADJUST_DEAL_FLOOR, pb.Operation_OPERATION_REPLACE, "/imp/1/deals/deal-a", ""},
+		{pb.Intent_ADJUST_DEAL_MARGIN, pb.Operation_OPERATION_REPLACE, "/imp/1/pmp/deals", ""},
+		{pb.Intent_BID_SHADE, pb.Operation_OPERATION_REPLACE, "/seatbid/seat-1/bid/bid-1", ""},
+		{pb.Intent_ADD_METRICS, pb.Operation_OPERATION_ADD, "/imp/1", ""},
+		{pb.Intent_ADD_CIDS, pb.Operation_OPERATION_ADD, "/app/content/data", ""},
+
+		{pb.Intent_INTENT_UNSPECIFIED, pb.Operation_OPERATION_ADD, "/imp/1", ReasonUnsupportedIntent},
+		{pb.Intent_ACTIVATE_SEGMENTS, pb.Operation_OPERATION_REPLACE, "/user/data/segment", ReasonUnsupportedOperation},
+		{pb.Intent_ADJUST_DEAL_FLOOR, pb.Operation_OPERATION_REPLACE, "/imp/1", ReasonInvalidPath},
+		{pb.Intent_BID_SHADE, pb.Operation_OPERATION_REPLACE, "/imp/1/pmp/deals/deal-a", ReasonInvalidPath},
+		{pb.Intent_ADD_CIDS, pb.Operation_OPERATION_ADD, "/site/content", ReasonInvalidPath},
+	}
+	for _, tt := range tests {
+		_, err := CheckPath(tt.intent, tt.op, tt.path)
+		var got Reason
+		if err != nil {
+			got = err.Reason
+		}
+		if got != tt.want {
+			t.Errorf("CheckPath(%v, %v, %q) = %q, want %q", tt.intent, tt.op, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		intent pb.Intent
+		op     pb.Operation
+		path   string
+		noReq  bool
+		noResp bool
+		want   Reason
+	}{
+		{name: "imp", intent: pb.Intent_ACTIVATE_DEALS, op: pb.Operation_OPERATION_ADD, path: "/imp/1"},
+		{name: "escaped imp", intent: pb.Intent_ADD_METRICS, op: pb.Operation_OPERATION_ADD, path: "/imp/a~1b~0c/metric"},
+		{name: "unescaped imp", intent: pb.Intent_ADD_METRICS, op: pb.Operation_OPERATION_ADD, path: "/imp/a/b~c/metric", want: ReasonInvalidPath},
+		{name: "deal", intent: pb.Intent_ADJUST_DEAL_FLOOR, op: pb.Operation_OPERATION_REPLACE, path: "/imp/1/pmp/deals/deal-b"},
+		{name: "missing deal", intent: pb.Intent_ADJUST_DEAL_FLOOR, op: pb.Operation_OPERATION_REPLACE, path: "/imp/1/pmp/deals/deal-z", want: ReasonTargetNotFound},
+		{name: "missing imp", intent: pb.Intent_ACTIVATE_DEALS, op: pb.Operation_OPERATION_ADD, path: "/imp/2", want: ReasonTargetNotFound},
+		{name: "bid", intent: pb.Intent_BID_SHADE, op: pb.Operation_OPERATION_REPLACE, path: "/seatbid/seat-1/bid/bid-1"},
+		{name: "site content", intent: pb.Intent_ADD_CIDS, op: pb.Operation_OPERATION_ADD, path: "/site/content/data"},
+		{name: "app content", intent: pb.Intent_ADD_CIDS, op: pb.Operation_OPERATION_ADD, path: "/app/content/data", want: ReasonTargetNotFound},
+		{name: "no request", intent: pb.Intent_ACTIVATE_DEALS, op: pb.Operation_OPERATION_ADD, path: "/imp/1", noReq: true, want: ReasonMissingPayload},
+		{name: "no response", intent: pb.Intent_BID_SHADE, op: pb.Operation_OPERATION_REPLACE, path: "/seatbid/seat-1/bid/bid-1", noResp: true, want: ReasonMissingPayload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, resp := testRequest(), testResponse()
+			if tt.noReq {
+				req = nil
+			}
+			if tt.noResp {
+				resp = nil
+			}
+			_, err := ValidatePath(tt.intent, tt.op, tt.path, req, resp)
+			var got Reason
+			if err != nil {
+				got = err.Reason
+			}
+			if got != tt.want {
+				t.Errorf("ValidatePath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.6.0
-// - protoc             v3.21.12
-// source: agenticrtbframework.proto
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: agenticrtbframeworkservices.proto
 
 package artf
 
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RTBExtensionPoint_GetMutations_FullMethodName = "/com.iabtechlab.bidstream.mutation.services.v1.RTBExtensionPoint/GetMutations"
+	RTBExtensionPoint_GetMutations_FullMethodName    = "/com.iabtechlab.bidstream.mutation.services.v1.RTBExtensionPoint/GetMutations"
+	RTBExtensionPoint_StreamMutations_FullMethodName = "/com.iabtechlab.bidstream.mutation.services.v1.RTBExtensionPoint/StreamMutations"
 )
 
 // RTBExtensionPointClient is the client API for RTBExtensionPoint service.
@@ -28,6 +29,10 @@ const (
 type RTBExtensionPointClient interface {
 	// GetMutations returns RTBResponse containing mutations to be applied at the predetermined auction lifecycle event
 	GetMutations(ctx context.Context, in *RTBRequest, opts ...grpc.CallOption) (*RTBResponse, error)
+	// StreamMutations carries many requests over a single stream. Each RTBResponse answers the
+	// RTBRequest with the same id and is sent as soon as it is ready, so responses may arrive
+	// in a different order than the requests. Every request is processed within its own tmax.
+	StreamMutations(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RTBRequest, RTBResponse], error)
 }
 
 type rTBExtensionPointClient struct {
@@ -48,12 +53,29 @@ func (c *rTBExtensionPointClient) GetMutations(ctx context.Context, in *RTBReque
 	return out, nil
 }
 
+func (c *rTBExtensionPointClient) StreamMutations(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RTBRequest, RTBResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RTBExtensionPoint_ServiceDesc.Streams[0], RTBExtensionPoint_StreamMutations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RTBRequest, RTBResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RTBExtensionPoint_StreamMutationsClient = grpc.BidiStreamingClient[RTBRequest, RTBResponse]
+
 // RTBExtensionPointServer is the server API for RTBExtensionPoint service.
 // All implementations must embed UnimplementedRTBExtensionPointServer
 // for forward compatibility.
 type RTBExtensionPointServer interface {
 	// GetMutations returns RTBResponse containing mutations to be applied at the predetermined auction lifecycle event
 	GetMutations(context.Context, *RTBRequest) (*RTBResponse, error)
+	// StreamMutations carries many requests over a single stream. Each RTBResponse answers the
+	// RTBRequest with the same id and is sent as soon as it is ready, so responses may arrive
+	// in a different order than the requests. Every request is processed within its own tmax.
+	StreamMutations(grpc.BidiStreamingServer[RTBRequest, RTBResponse]) error
 	mustEmbedUnimplementedRTBExtensionPointServer()
 }
 
@@ -65,7 +87,10 @@ type RTBExtensionPointServer interface {
 type UnimplementedRTBExtensionPointServer struct{}
 
 func (UnimplementedRTBExtensionPointServer) GetMutations(context.Context, *RTBRequest) (*RTBResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetMutations not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutations not implemented")
+}
+func (UnimplementedRTBExtensionPointServer) StreamMutations(grpc.BidiStreamingServer[RTBRequest, RTBResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMutations not implemented")
 }
 func (UnimplementedRTBExtensionPointServer) mustEmbedUnimplementedRTBExtensionPointServer() {}
 func (UnimplementedRTBExtensionPointServer) testEmbeddedByValue()                           {}
@@ -78,7 +103,7 @@ type UnsafeRTBExtensionPointServer interface {
 }
 
 func RegisterRTBExtensionPointServer(s grpc.ServiceRegistrar, srv RTBExtensionPointServer) {
-	// If the following call panics, it indicates UnimplementedRTBExtensionPointServer was
+	// If the following call pancis, it indicates UnimplementedRTBExtensionPointServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
@@ -106,6 +131,13 @@ func _RTBExtensionPoint_GetMutations_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RTBExtensionPoint_StreamMutations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RTBExtensionPointServer).StreamMutations(&grpc.GenericServerStream[RTBRequest, RTBResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RTBExtensionPoint_StreamMutationsServer = grpc.BidiStreamingServer[RTBRequest, RTBResponse]
+
 // RTBExtensionPoint_ServiceDesc is the grpc.ServiceDesc for RTBExtensionPoint service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -118,6 +150,13 @@ var RTBExtensionPoint_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _RTBExtensionPoint_GetMutations_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "agenticrtbframework.proto",
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMutations",
+			Handler:       _RTBExtensionPoint_StreamMutations_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "agenticrtbframeworkservices.proto",
 }
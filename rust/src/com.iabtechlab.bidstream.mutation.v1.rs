@@ -152,6 +152,10 @@ pub struct Metadata {
     /// The model version utilized by the container or API to compute the mutation if applicable
     #[prost(string, tag = "2")]
     pub model_version: ::prost::alloc::string::String,
+    /// Names of handlers that did not finish before the tmax deadline.
+    /// Mutations from these handlers are not included in the response.
+    #[prost(string, repeated, tag = "3")]
+    pub timed_out_handlers: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
 }
 #[derive(Clone, PartialEq, Eq, Hash, ::prost::Message)]
 pub struct IDsPayload {
@@ -11,6 +11,7 @@ pub fn build_metadata() -> Metadata {
     Metadata {
         api_version: API_VERSION.to_string(),
         model_version: MODEL_VERSION.to_string(),
+        timed_out_handlers: Vec::new(),
     }
 }
 
@@ -40,10 +40,12 @@ protoc \
 echo "  - ARTF service and types..."
 protoc \
   --proto_path="$PROTO_DIR" \
+  --experimental_editions \
   --go_out="$PROJECT_ROOT" \
   --go_opt=module=github.com/iabtechlab/agentic-rtb-framework \
   --go-grpc_out="$PROJECT_ROOT" \
   --go-grpc_opt=module=github.com/iabtechlab/agentic-rtb-framework \
-  "$PROTO_DIR/agenticrtbframework.proto"
+  "$PROTO_DIR/agenticrtbframework.proto" \
+  "$PROTO_DIR/agenticrtbframeworkservices.proto"
 
 echo "Done! Generated files in $OUT_DIR"